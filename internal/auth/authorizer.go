@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Authorizer checks whether an authenticated caller may act on a specific
+// resource via SubjectAccessReview, so RBAC configured on the cluster
+// stays the single source of truth.
+type Authorizer struct {
+	client kubernetes.Interface
+}
+
+// NewAuthorizer builds an Authorizer that submits SubjectAccessReviews
+// through client.
+func NewAuthorizer(client kubernetes.Interface) *Authorizer {
+	return &Authorizer{client: client}
+}
+
+// Authorize reports whether info may perform verb against the resource
+// identified by gvr/namespace/name.
+func (a *Authorizer) Authorize(ctx context.Context, info user.Info, verb string, gvr schema.GroupVersionResource, namespace, name string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(info.GetExtra()))
+	for k, v := range info.GetExtra() {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   info.GetName(),
+			UID:    info.GetUID(),
+			Groups: info.GetGroups(),
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     gvr.Group,
+				Version:   gvr.Version,
+				Resource:  gvr.Resource,
+				Name:      name,
+			},
+		},
+	}
+
+	result, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("subject access review failed: %w", err)
+	}
+	return result.Status.Allowed, nil
+}
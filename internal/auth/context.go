@@ -0,0 +1,29 @@
+// Package auth authenticates callers via Kubernetes ServiceAccount bearer
+// tokens or OIDC ID tokens, and authorizes their requests against the
+// target Crossplane resource using SubjectAccessReview, so cluster RBAC
+// stays the single source of truth.
+package auth
+
+import (
+	"context"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+type contextKey int
+
+const userInfoKey contextKey = iota
+
+// WithUser returns a copy of ctx carrying the authenticated caller's
+// identity.
+func WithUser(ctx context.Context, info user.Info) context.Context {
+	return context.WithValue(ctx, userInfoKey, info)
+}
+
+// UserFrom returns the authenticated caller's identity previously stored by
+// the auth middleware, or (nil, false) if the request was never
+// authenticated.
+func UserFrom(ctx context.Context) (user.Info, bool) {
+	info, ok := ctx.Value(userInfoKey).(user.Info)
+	return info, ok
+}
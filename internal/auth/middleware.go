@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+// Authenticator validates a bearer token and returns the identity it maps
+// to. The second return value is false (with a nil error) if the token is
+// not one this Authenticator recognizes, so Middleware can try the next
+// one configured.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (user.Info, bool, error)
+}
+
+// Middleware returns a mux middleware that authenticates every request's
+// "Authorization: Bearer <token>" header against authenticators in order,
+// and injects the resulting user.Info into the request context. Requests
+// with no recognized token are rejected with 401.
+func Middleware(authenticators ...Authenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			for _, authenticator := range authenticators {
+				info, ok, err := authenticator.Authenticate(r.Context(), token)
+				if err != nil || !ok {
+					continue
+				}
+				next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), info)))
+				return
+			}
+
+			http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(header, prefix), true
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	oidc "github.com/coreos/go-oidc/v3/oidc"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+// OIDCConfig configures validation of OIDC ID tokens issued by an external
+// identity provider.
+type OIDCConfig struct {
+	IssuerURL     string
+	ClientID      string
+	UsernameClaim string // defaults to "sub"
+	GroupsClaim   string // optional
+}
+
+// OIDCAuthenticator validates bearer tokens as OIDC ID tokens signed by a
+// configured issuer, resolving its JWKS automatically via OIDC discovery.
+type OIDCAuthenticator struct {
+	verifier      *oidc.IDTokenVerifier
+	usernameClaim string
+	groupsClaim   string
+}
+
+// NewOIDCAuthenticator fetches cfg.IssuerURL's OIDC discovery document and
+// builds an Authenticator around it.
+func NewOIDCAuthenticator(ctx context.Context, cfg OIDCConfig) (*OIDCAuthenticator, error) {
+	provider, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC issuer %q: %w", cfg.IssuerURL, err)
+	}
+
+	usernameClaim := cfg.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "sub"
+	}
+
+	return &OIDCAuthenticator{
+		verifier:      provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+		usernameClaim: usernameClaim,
+		groupsClaim:   cfg.GroupsClaim,
+	}, nil
+}
+
+// Authenticate verifies token as an OIDC ID token. The second return value
+// is false (with a nil error) if token doesn't verify against this
+// issuer, so callers can fall through to another Authenticator.
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, token string) (user.Info, bool, error) {
+	idToken, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, false, fmt.Errorf("failed to parse ID token claims: %w", err)
+	}
+
+	username, _ := claims[a.usernameClaim].(string)
+	if username == "" {
+		username = idToken.Subject
+	}
+
+	var groups []string
+	if a.groupsClaim != "" {
+		if raw, ok := claims[a.groupsClaim].([]interface{}); ok {
+			for _, g := range raw {
+				if s, ok := g.(string); ok {
+					groups = append(groups, s)
+				}
+			}
+		}
+	}
+
+	return &user.DefaultInfo{Name: username, Groups: groups}, true, nil
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ServiceAccountAuthenticator validates bearer tokens via the Kubernetes
+// TokenReview API, so it recognizes ServiceAccount tokens (and anything
+// else the cluster's configured authenticators accept).
+type ServiceAccountAuthenticator struct {
+	client kubernetes.Interface
+}
+
+// NewServiceAccountAuthenticator builds a ServiceAccountAuthenticator that
+// submits TokenReviews through client.
+func NewServiceAccountAuthenticator(client kubernetes.Interface) *ServiceAccountAuthenticator {
+	return &ServiceAccountAuthenticator{client: client}
+}
+
+// Authenticate submits token to the API server's TokenReview endpoint. The
+// second return value is false (with a nil error) if the token was
+// well-formed but rejected, so callers can fall through to another
+// Authenticator.
+func (a *ServiceAccountAuthenticator) Authenticate(ctx context.Context, token string) (user.Info, bool, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+
+	result, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, false, fmt.Errorf("token review failed: %w", err)
+	}
+	if !result.Status.Authenticated {
+		return nil, false, nil
+	}
+
+	extra := make(map[string][]string, len(result.Status.User.Extra))
+	for k, v := range result.Status.User.Extra {
+		extra[k] = []string(v)
+	}
+
+	info := &user.DefaultInfo{
+		Name:   result.Status.User.Username,
+		UID:    result.Status.User.UID,
+		Groups: result.Status.User.Groups,
+		Extra:  extra,
+	}
+	return info, true, nil
+}
@@ -0,0 +1,28 @@
+package blueprint
+
+import "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+// ClaimSpec describes a claim to create against a registered blueprint.
+type ClaimSpec struct {
+	Name       string
+	Namespace  string
+	Parameters map[string]interface{}
+}
+
+// NewClaim builds the unstructured Claim object for spec. apiVersion and
+// kind come from the target blueprint's XRD (see ClaimGVR and ClaimKind).
+func NewClaim(apiVersion, kind string, spec ClaimSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"parameters": spec.Parameters,
+			},
+		},
+	}
+}
@@ -0,0 +1,23 @@
+// Package blueprint lets platform teams publish opinionated Crossplane
+// Compositions as claimable "blueprints", so callers provision a whole
+// stack (e.g. a VM with an attached volume) through one claim instead of
+// assembling the underlying managed resources themselves.
+package blueprint
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// XRDGVR is the Crossplane CompositeResourceDefinition resource, which
+// declares a blueprint's claim kind and schema.
+var XRDGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.crossplane.io",
+	Version:  "v1",
+	Resource: "compositeresourcedefinitions",
+}
+
+// CompositionGVR is the Crossplane Composition resource, which implements
+// an XRD by composing managed resources together.
+var CompositionGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.crossplane.io",
+	Version:  "v1",
+	Resource: "compositions",
+}
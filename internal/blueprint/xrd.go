@@ -0,0 +1,76 @@
+package blueprint
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// ListXRDs returns every CompositeResourceDefinition registered in the
+// cluster. Blueprints have no separate bookkeeping of their own: the set of
+// registered XRDs is the set of published blueprints.
+func ListXRDs(ctx context.Context, client dynamic.Interface) ([]unstructured.Unstructured, error) {
+	list, err := client.Resource(XRDGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+	return list.Items, nil
+}
+
+// ClaimGVR derives the GroupVersionResource used to create and list Claims
+// against xrd, from its spec.group, a served spec.versions entry, and
+// spec.claimNames.plural.
+func ClaimGVR(xrd *unstructured.Unstructured) (schema.GroupVersionResource, error) {
+	group, found, err := unstructured.NestedString(xrd.Object, "spec", "group")
+	if err != nil || !found || group == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("XRD %q has no spec.group", xrd.GetName())
+	}
+
+	plural, found, err := unstructured.NestedString(xrd.Object, "spec", "claimNames", "plural")
+	if err != nil || !found || plural == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("XRD %q does not offer a claim (no spec.claimNames.plural)", xrd.GetName())
+	}
+
+	version, err := servedVersion(xrd)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: plural}, nil
+}
+
+// ClaimKind returns the Kind clients must set on a Claim created against xrd.
+func ClaimKind(xrd *unstructured.Unstructured) (string, error) {
+	kind, found, err := unstructured.NestedString(xrd.Object, "spec", "claimNames", "kind")
+	if err != nil || !found || kind == "" {
+		return "", fmt.Errorf("XRD %q does not offer a claim (no spec.claimNames.kind)", xrd.GetName())
+	}
+	return kind, nil
+}
+
+func servedVersion(xrd *unstructured.Unstructured) (string, error) {
+	versions, found, err := unstructured.NestedSlice(xrd.Object, "spec", "versions")
+	if err != nil || !found {
+		return "", fmt.Errorf("XRD %q has no spec.versions", xrd.GetName())
+	}
+
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		served, _, _ := unstructured.NestedBool(version, "served")
+		if !served {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(version, "name")
+		if name != "" {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("XRD %q has no served version", xrd.GetName())
+}
@@ -0,0 +1,103 @@
+package blueprint
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func xrdWithVersions(versions ...interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "test-xrd"},
+			"spec": map[string]interface{}{
+				"group": "example.org",
+				"claimNames": map[string]interface{}{
+					"kind":   "TestClaim",
+					"plural": "testclaims",
+				},
+				"versions": versions,
+			},
+		},
+	}
+}
+
+func TestServedVersionPicksServedEntry(t *testing.T) {
+	xrd := xrdWithVersions(
+		map[string]interface{}{"name": "v1alpha1", "served": false},
+		map[string]interface{}{"name": "v1beta1", "served": true},
+	)
+
+	version, err := servedVersion(xrd)
+	if err != nil {
+		t.Fatalf("servedVersion returned error: %v", err)
+	}
+	if version != "v1beta1" {
+		t.Errorf("servedVersion = %q, want %q", version, "v1beta1")
+	}
+}
+
+func TestServedVersionNoneServed(t *testing.T) {
+	xrd := xrdWithVersions(
+		map[string]interface{}{"name": "v1alpha1", "served": false},
+	)
+
+	if _, err := servedVersion(xrd); err == nil {
+		t.Error("servedVersion returned no error, want one for an XRD with no served version")
+	}
+}
+
+func TestServedVersionNoVersions(t *testing.T) {
+	xrd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "test-xrd"},
+			"spec":     map[string]interface{}{"group": "example.org"},
+		},
+	}
+
+	if _, err := servedVersion(xrd); err == nil {
+		t.Error("servedVersion returned no error, want one for an XRD with no spec.versions")
+	}
+}
+
+func TestClaimGVR(t *testing.T) {
+	xrd := xrdWithVersions(
+		map[string]interface{}{"name": "v1beta1", "served": true},
+	)
+
+	gvr, err := ClaimGVR(xrd)
+	if err != nil {
+		t.Fatalf("ClaimGVR returned error: %v", err)
+	}
+	if gvr.Group != "example.org" || gvr.Version != "v1beta1" || gvr.Resource != "testclaims" {
+		t.Errorf("ClaimGVR = %+v, want {Group:example.org Version:v1beta1 Resource:testclaims}", gvr)
+	}
+}
+
+func TestClaimGVRMissingClaimNames(t *testing.T) {
+	xrd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "test-xrd"},
+			"spec": map[string]interface{}{
+				"group":    "example.org",
+				"versions": []interface{}{map[string]interface{}{"name": "v1beta1", "served": true}},
+			},
+		},
+	}
+
+	if _, err := ClaimGVR(xrd); err == nil {
+		t.Error("ClaimGVR returned no error, want one for an XRD with no spec.claimNames.plural")
+	}
+}
+
+func TestClaimKind(t *testing.T) {
+	xrd := xrdWithVersions(map[string]interface{}{"name": "v1beta1", "served": true})
+
+	kind, err := ClaimKind(xrd)
+	if err != nil {
+		t.Fatalf("ClaimKind returned error: %v", err)
+	}
+	if kind != "TestClaim" {
+		t.Errorf("ClaimKind = %q, want %q", kind, "TestClaim")
+	}
+}
@@ -0,0 +1,48 @@
+package crossplane
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/Atmajyoti208/crossplane-go/internal/metrics"
+)
+
+// Apply server-side-applies obj against the given GVR, using FieldManager to
+// identify this API server as the owner of the fields it sets. It returns
+// the object as observed by the API server after the apply.
+func Apply(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	applied, err := apply(ctx, client, gvr, obj)
+
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	metrics.ApplyTotal.WithLabelValues(obj.GroupVersionKind().String(), result).Inc()
+
+	return applied, err
+}
+
+func apply(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Resource(gvr).Namespace(obj.GetNamespace()).Patch(
+		ctx,
+		obj.GetName(),
+		types.ApplyPatchType,
+		data,
+		metav1.PatchOptions{FieldManager: FieldManager, Force: boolPtr(true)},
+	)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,113 @@
+package crossplane
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AWSInstanceSpec describes the fields a caller may set when provisioning an
+// EC2 instance via the upbound-provider-aws Instance CR.
+type AWSInstanceSpec struct {
+	Name             string
+	Namespace        string
+	AMI              string
+	InstanceType     string
+	SubnetID         string
+	SecurityGroupIDs []string
+}
+
+// NewAWSInstance builds the unstructured Instance object for an EC2 VM.
+func NewAWSInstance(spec AWSInstanceSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ec2.aws.upbound.io/v1beta1",
+			"kind":       "Instance",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"forProvider": map[string]interface{}{
+					"ami":                 spec.AMI,
+					"instanceType":        spec.InstanceType,
+					"subnetId":            spec.SubnetID,
+					"vpcSecurityGroupIds": toInterfaceSlice(spec.SecurityGroupIDs),
+					"tags":                map[string]interface{}{"Name": spec.Name},
+				},
+				"providerConfigRef": map[string]interface{}{
+					"name": awsProviderConfigName,
+				},
+			},
+		},
+	}
+}
+
+// AWSVolumeSpec describes the fields a caller may set when provisioning an
+// EBS volume via the upbound-provider-aws Volume CR.
+type AWSVolumeSpec struct {
+	Name             string
+	Namespace        string
+	Size             int
+	AvailabilityZone string
+}
+
+// NewAWSVolume builds the unstructured Volume object for an EBS volume.
+func NewAWSVolume(spec AWSVolumeSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ebs.aws.upbound.io/v1beta1",
+			"kind":       "Volume",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"forProvider": map[string]interface{}{
+					"size":             spec.Size,
+					"availabilityZone": spec.AvailabilityZone,
+				},
+				"providerConfigRef": map[string]interface{}{
+					"name": awsProviderConfigName,
+				},
+			},
+		},
+	}
+}
+
+// AWSVolumeAttachmentSpec describes the fields a caller may set when
+// attaching an EBS volume to an EC2 instance.
+type AWSVolumeAttachmentSpec struct {
+	Name       string
+	Namespace  string
+	InstanceID string
+	VolumeID   string
+	DeviceName string
+}
+
+// NewAWSVolumeAttachment builds the unstructured VolumeAttachment object
+// linking an EBS volume to an EC2 instance.
+func NewAWSVolumeAttachment(spec AWSVolumeAttachmentSpec) *unstructured.Unstructured {
+	deviceName := spec.DeviceName
+	if deviceName == "" {
+		deviceName = "/dev/sdf"
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ec2.aws.upbound.io/v1beta1",
+			"kind":       "VolumeAttachment",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"forProvider": map[string]interface{}{
+					"instanceId": spec.InstanceID,
+					"volumeId":   spec.VolumeID,
+					"deviceName": deviceName,
+				},
+				"providerConfigRef": map[string]interface{}{
+					"name": awsProviderConfigName,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package crossplane
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GCPInstanceSpec describes the fields a caller may set when provisioning a
+// Compute Engine instance via the upbound-provider-gcp Instance CR.
+type GCPInstanceSpec struct {
+	Name        string
+	Namespace   string
+	Zone        string
+	MachineType string
+	Image       string
+	Network     string
+}
+
+// NewGCPInstance builds the unstructured Instance object for a Compute
+// Engine VM.
+func NewGCPInstance(spec GCPInstanceSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "compute.gcp.upbound.io/v1beta1",
+			"kind":       "Instance",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"forProvider": map[string]interface{}{
+					"zone":        spec.Zone,
+					"machineType": spec.MachineType,
+					"bootDisk": []interface{}{
+						map[string]interface{}{
+							"initializeParams": []interface{}{
+								map[string]interface{}{"image": spec.Image},
+							},
+						},
+					},
+					"networkInterface": []interface{}{
+						map[string]interface{}{"network": spec.Network},
+					},
+				},
+				"providerConfigRef": map[string]interface{}{
+					"name": gcpProviderConfigName,
+				},
+			},
+		},
+	}
+}
+
+// GCPDiskSpec describes the fields a caller may set when provisioning a
+// persistent disk via the upbound-provider-gcp Disk CR.
+type GCPDiskSpec struct {
+	Name      string
+	Namespace string
+	Zone      string
+	SizeGB    int
+}
+
+// NewGCPDisk builds the unstructured Disk object for a persistent disk.
+func NewGCPDisk(spec GCPDiskSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "compute.gcp.upbound.io/v1beta1",
+			"kind":       "Disk",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"forProvider": map[string]interface{}{
+					"zone": spec.Zone,
+					"size": spec.SizeGB,
+				},
+				"providerConfigRef": map[string]interface{}{
+					"name": gcpProviderConfigName,
+				},
+			},
+		},
+	}
+}
+
+// GCPAttachedDiskSpec describes the fields a caller may set when attaching a
+// persistent disk to a Compute Engine instance.
+type GCPAttachedDiskSpec struct {
+	Name      string
+	Namespace string
+	Instance  string
+	Disk      string
+	Zone      string
+}
+
+// NewGCPAttachedDisk builds the unstructured AttachedDisk object linking a
+// persistent disk to a Compute Engine instance.
+func NewGCPAttachedDisk(spec GCPAttachedDiskSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "compute.gcp.upbound.io/v1beta1",
+			"kind":       "AttachedDisk",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"forProvider": map[string]interface{}{
+					"instance": spec.Instance,
+					"disk":     spec.Disk,
+					"zone":     spec.Zone,
+				},
+				"providerConfigRef": map[string]interface{}{
+					"name": gcpProviderConfigName,
+				},
+			},
+		},
+	}
+}
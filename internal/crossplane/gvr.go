@@ -0,0 +1,82 @@
+// Package crossplane provides a typed-ish helper layer over the managed
+// resources of the Crossplane OpenStack, AWS, and GCP providers, built on
+// top of the dynamic client.
+package crossplane
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// GroupVersionResources for the OpenStack Crossplane provider CRs this API
+// server manages.
+var (
+	InstanceV2GVR = schema.GroupVersionResource{
+		Group:    "compute.openstack.crossplane.io",
+		Version:  "v1alpha1",
+		Resource: "instancev2s",
+	}
+
+	VolumeV3GVR = schema.GroupVersionResource{
+		Group:    "blockstorage.openstack.crossplane.io",
+		Version:  "v1alpha1",
+		Resource: "volumev3s",
+	}
+
+	VolumeAttachmentV2GVR = schema.GroupVersionResource{
+		Group:    "compute.openstack.crossplane.io",
+		Version:  "v1alpha1",
+		Resource: "volumeattachmentv2s",
+	}
+)
+
+// GroupVersionResources for the upbound-provider-aws CRs this API server
+// manages.
+var (
+	AWSInstanceGVR = schema.GroupVersionResource{
+		Group:    "ec2.aws.upbound.io",
+		Version:  "v1beta1",
+		Resource: "instances",
+	}
+
+	AWSVolumeGVR = schema.GroupVersionResource{
+		Group:    "ebs.aws.upbound.io",
+		Version:  "v1beta1",
+		Resource: "volumes",
+	}
+
+	AWSVolumeAttachmentGVR = schema.GroupVersionResource{
+		Group:    "ec2.aws.upbound.io",
+		Version:  "v1beta1",
+		Resource: "volumeattachments",
+	}
+)
+
+// GroupVersionResources for the upbound-provider-gcp CRs this API server
+// manages.
+var (
+	GCPInstanceGVR = schema.GroupVersionResource{
+		Group:    "compute.gcp.upbound.io",
+		Version:  "v1beta1",
+		Resource: "instances",
+	}
+
+	GCPDiskGVR = schema.GroupVersionResource{
+		Group:    "compute.gcp.upbound.io",
+		Version:  "v1beta1",
+		Resource: "disks",
+	}
+
+	GCPAttachedDiskGVR = schema.GroupVersionResource{
+		Group:    "compute.gcp.upbound.io",
+		Version:  "v1beta1",
+		Resource: "attacheddisks",
+	}
+)
+
+const (
+	providerConfigName    = "provider-openstack-config"
+	awsProviderConfigName = "provider-aws-config"
+	gcpProviderConfigName = "provider-gcp-config"
+)
+
+// FieldManager identifies this API server to the Kubernetes API server when
+// it performs server-side apply.
+const FieldManager = "crossplane-go-api"
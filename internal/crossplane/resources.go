@@ -0,0 +1,116 @@
+package crossplane
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// InstanceSpec describes the fields a caller may set when provisioning an
+// OpenStack VM via the InstanceV2 CR.
+type InstanceSpec struct {
+	Name           string
+	Namespace      string
+	ImageID        string
+	FlavorID       string
+	NetworkID      string
+	SecurityGroups []string
+}
+
+// NewInstanceV2 builds the unstructured InstanceV2 object for an OpenStack VM.
+func NewInstanceV2(spec InstanceSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "compute.openstack.crossplane.io/v1alpha1",
+			"kind":       "InstanceV2",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"forProvider": map[string]interface{}{
+					"configDrive":    true,
+					"flavorId":       spec.FlavorID,
+					"imageId":        spec.ImageID,
+					"name":           spec.Name,
+					"network":        []interface{}{map[string]interface{}{"uuid": spec.NetworkID}},
+					"securityGroups": toInterfaceSlice(spec.SecurityGroups),
+				},
+				"providerConfigRef": map[string]interface{}{
+					"name": providerConfigName,
+				},
+			},
+		},
+	}
+}
+
+// VolumeSpec describes the fields a caller may set when provisioning an
+// OpenStack Cinder volume via the VolumeV3 CR.
+type VolumeSpec struct {
+	Name        string
+	Namespace   string
+	Size        int
+	Description string
+}
+
+// NewVolumeV3 builds the unstructured VolumeV3 object for a block volume.
+func NewVolumeV3(spec VolumeSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "blockstorage.openstack.crossplane.io/v1alpha1",
+			"kind":       "VolumeV3",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"forProvider": map[string]interface{}{
+					"name":        spec.Name,
+					"size":        spec.Size,
+					"description": spec.Description,
+				},
+				"providerConfigRef": map[string]interface{}{
+					"name": providerConfigName,
+				},
+			},
+		},
+	}
+}
+
+// AttachmentSpec describes the fields a caller may set when attaching a
+// volume to an instance via the VolumeAttachmentV2 CR.
+type AttachmentSpec struct {
+	Name       string
+	Namespace  string
+	InstanceID string
+	VolumeID   string
+}
+
+// NewVolumeAttachmentV2 builds the unstructured VolumeAttachmentV2 object
+// linking a volume to an instance.
+func NewVolumeAttachmentV2(spec AttachmentSpec) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "compute.openstack.crossplane.io/v1alpha1",
+			"kind":       "VolumeAttachmentV2",
+			"metadata": map[string]interface{}{
+				"name":      spec.Name,
+				"namespace": spec.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"instanceId": spec.InstanceID,
+				"volumeId":   spec.VolumeID,
+				"providerConfigRef": map[string]interface{}{
+					"name": providerConfigName,
+				},
+				"deletionPolicy": "Delete",
+			},
+		},
+	}
+}
+
+func toInterfaceSlice(in []string) []interface{} {
+	out := make([]interface{}, len(in))
+	for i, v := range in {
+		out[i] = v
+	}
+	return out
+}
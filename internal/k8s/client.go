@@ -0,0 +1,70 @@
+// Package k8s wires up the Kubernetes clients used by the API server.
+package k8s
+
+import (
+	"fmt"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Clients bundles the typed, dynamic, and discovery clients the handlers
+// need.
+type Clients struct {
+	Config    *rest.Config
+	Typed     kubernetes.Interface
+	Dynamic   dynamic.Interface
+	Discovery discovery.DiscoveryInterface
+}
+
+// NewClients builds a Clients using the in-cluster service account. The API
+// server is expected to run as a pod inside the cluster it manages.
+func NewClients() (*Clients, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load in-cluster config: %w", err)
+	}
+
+	return newClients(cfg)
+}
+
+func newClients(cfg *rest.Config) (*Clients, error) {
+	typed, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build typed client: %w", err)
+	}
+
+	dyn, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dynamic client: %w", err)
+	}
+
+	disc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery client: %w", err)
+	}
+
+	return &Clients{Config: cfg, Typed: typed, Dynamic: dyn, Discovery: disc}, nil
+}
+
+// Impersonate returns a new Clients that acts as info rather than this
+// server's own service account, so Kubernetes RBAC is evaluated against the
+// original caller rather than the API server's identity.
+func (c *Clients) Impersonate(info user.Info) (*Clients, error) {
+	cfg := rest.CopyConfig(c.Config)
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: info.GetName(),
+		UID:      info.GetUID(),
+		Groups:   info.GetGroups(),
+		Extra:    info.GetExtra(),
+	}
+
+	clients, err := newClients(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build impersonated client for %q: %w", info.GetName(), err)
+	}
+	return clients, nil
+}
@@ -0,0 +1,22 @@
+// Package logging builds the structured logr.Logger this API server uses
+// so every handler emits consistent, machine-parseable JSON fields instead
+// of ad-hoc log.Printf calls.
+package logging
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+// New builds a JSON-encoded, production-configured logr.Logger. The
+// returned func flushes buffered log entries and should be deferred by the
+// caller.
+func New() (logr.Logger, func(), error) {
+	zapLog, err := zap.NewProduction()
+	if err != nil {
+		return logr.Logger{}, nil, err
+	}
+	sync := func() { _ = zapLog.Sync() }
+	return zapr.NewLogger(zapLog), sync, nil
+}
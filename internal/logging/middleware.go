@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Middleware logs one structured entry per request, with duration_ms and
+// http_status fields, so operators can correlate API activity without
+// grepping plaintext logs.
+func Middleware(logger logr.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			logger.Info("handled request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"http_status", rec.status,
+				"duration_ms", time.Since(start).Milliseconds(),
+			)
+		})
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,42 @@
+// Package metrics exposes the Prometheus collectors this API server
+// reports at /metrics.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// RequestsTotal counts HTTP requests served, by route, method, and
+	// response status code.
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "crossplane_api_requests_total",
+		Help: "Total HTTP requests served, by handler, method, and status code.",
+	}, []string{"handler", "method", "code"})
+
+	// RequestDuration tracks HTTP request latency, by route and method.
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "crossplane_api_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, by handler and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"handler", "method"})
+
+	// ApplyTotal counts Crossplane managed resource applies, by GVK and
+	// whether the apply succeeded.
+	ApplyTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "crossplane_apply_total",
+		Help: "Total Crossplane managed resource applies, by GVK and result.",
+	}, []string{"gvk", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(RequestsTotal, RequestDuration, ApplyTotal)
+}
+
+// RegisterRunningOperations wires fn as the source of the
+// crossplane_api_running_operations gauge, sampled at scrape time, so the
+// operations subsystem doesn't need to keep a separate counter in sync.
+func RegisterRunningOperations(fn func() float64) {
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "crossplane_api_running_operations",
+		Help: "Number of async operations currently running.",
+	}, fn))
+}
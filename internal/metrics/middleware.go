@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Middleware records RequestsTotal and RequestDuration for every request,
+// labeling it with the matched route's path template so dynamic segments
+// (team/VM names) don't blow up cardinality.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		handler := "unmatched"
+		if route := mux.CurrentRoute(r); route != nil {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				handler = tmpl
+			}
+		}
+
+		RequestsTotal.WithLabelValues(handler, r.Method, strconv.Itoa(rec.status)).Inc()
+		RequestDuration.WithLabelValues(handler, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder captures the status code a handler writes, since
+// http.ResponseWriter doesn't expose it directly, while still forwarding
+// Flush so SSE handlers keep working.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
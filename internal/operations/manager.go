@@ -0,0 +1,218 @@
+package operations
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned by Submit when the bounded work queue has no
+// room left for another operation.
+var ErrQueueFull = errors.New("operation queue is full")
+
+// ErrNotRunning is returned by Cancel when the operation is not currently
+// running (already finished, or unknown).
+var ErrNotRunning = errors.New("operation is not running")
+
+// Func performs the actual work behind an Operation. It must respect ctx
+// cancellation so Manager.Cancel can interrupt it.
+type Func func(ctx context.Context) (interface{}, error)
+
+type job struct {
+	id  string
+	ctx context.Context
+	fn  Func
+}
+
+// Manager runs submitted Funcs on a bounded pool of background workers and
+// tracks their progress as Operations.
+type Manager struct {
+	store *Store
+	queue chan job
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+	waiters map[string][]chan struct{}
+}
+
+// NewManager starts workers background goroutines pulling from a queue of
+// size queueSize, persisting operation state to store. Any operation left
+// in StatusRunning by a prior process (the work itself doesn't survive a
+// restart, since it only lives in an in-memory closure) is reconciled to
+// StatusFailed so callers polling it don't hang forever.
+func NewManager(store *Store, workers, queueSize int) *Manager {
+	m := &Manager{
+		store:   store,
+		queue:   make(chan job, queueSize),
+		cancels: make(map[string]context.CancelFunc),
+		waiters: make(map[string][]chan struct{}),
+	}
+	m.reconcileStaleOperations()
+	for i := 0; i < workers; i++ {
+		go m.worker()
+	}
+	return m
+}
+
+// reconcileStaleOperations marks every operation still StatusRunning from a
+// previous process as failed, since the Func behind it only ever lived in
+// that process's memory and cannot be resumed.
+func (m *Manager) reconcileStaleOperations() {
+	running, err := m.store.ListRunning()
+	if err != nil {
+		return
+	}
+	for _, op := range running {
+		op.Status = StatusFailed
+		op.Error = "operation was still running when the server restarted"
+		op.UpdatedAt = time.Now()
+		m.store.Put(op)
+	}
+}
+
+func (m *Manager) worker() {
+	for j := range m.queue {
+		result, err := j.fn(j.ctx)
+		m.finish(j.id, result, err)
+	}
+}
+
+// Submit records a new running Operation owned by namespace and enqueues
+// fn to run on a worker. It returns ErrQueueFull without running fn if the
+// queue has no capacity left.
+func (m *Manager) Submit(namespace string, fn Func) (*Operation, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate operation id: %w", err)
+	}
+
+	now := time.Now()
+	op := &Operation{
+		ID:        id,
+		Namespace: namespace,
+		Status:    StatusRunning,
+		Self:      "/operations/" + id,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := m.store.Put(op); err != nil {
+		return nil, fmt.Errorf("failed to persist operation: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.cancels[id] = cancel
+	m.mu.Unlock()
+
+	select {
+	case m.queue <- job{id: id, ctx: ctx, fn: fn}:
+	default:
+		cancel()
+		m.mu.Lock()
+		delete(m.cancels, id)
+		m.mu.Unlock()
+		return nil, ErrQueueFull
+	}
+
+	return op, nil
+}
+
+func (m *Manager) finish(id string, result interface{}, err error) {
+	op, getErr := m.store.Get(id)
+	if getErr != nil {
+		return
+	}
+
+	op.UpdatedAt = time.Now()
+	switch {
+	case errors.Is(err, context.Canceled):
+		op.Status = StatusCanceled
+	case err != nil:
+		op.Status = StatusFailed
+		op.Error = err.Error()
+	default:
+		op.Status = StatusSucceeded
+		if result != nil {
+			if data, marshalErr := marshalResult(result); marshalErr == nil {
+				op.Result = data
+			}
+		}
+	}
+
+	// Persist the terminal state under the same lock Wait, Running and
+	// Cancel take, so none of them sees a finished operation still counted
+	// as running.
+	m.mu.Lock()
+	m.store.Put(op)
+	delete(m.cancels, id)
+	waiters := m.waiters[id]
+	delete(m.waiters, id)
+	m.mu.Unlock()
+
+	for _, ch := range waiters {
+		close(ch)
+	}
+}
+
+// Get returns the current state of the operation with the given ID.
+func (m *Manager) Get(id string) (*Operation, error) {
+	return m.store.Get(id)
+}
+
+// Running reports how many operations are currently submitted and not yet
+// in a terminal state.
+func (m *Manager) Running() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.cancels)
+}
+
+// Wait blocks until the operation reaches a terminal state or ctx is done,
+// whichever comes first, then returns its current state.
+func (m *Manager) Wait(ctx context.Context, id string) (*Operation, error) {
+	m.mu.Lock()
+	op, err := m.store.Get(id)
+	if err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	if op.Done() {
+		m.mu.Unlock()
+		return op, nil
+	}
+	ch := make(chan struct{})
+	m.waiters[id] = append(m.waiters[id], ch)
+	m.mu.Unlock()
+
+	select {
+	case <-ch:
+	case <-ctx.Done():
+	}
+
+	return m.store.Get(id)
+}
+
+// Cancel requests that the running operation's context be canceled. The
+// worker running it is responsible for observing ctx.Done() and returning.
+func (m *Manager) Cancel(id string) error {
+	m.mu.Lock()
+	cancel, ok := m.cancels[id]
+	m.mu.Unlock()
+	if !ok {
+		return ErrNotRunning
+	}
+	cancel()
+	return nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,177 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	store, err := OpenStore(filepath.Join(t.TempDir(), "operations.db"))
+	if err != nil {
+		t.Fatalf("OpenStore returned error: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return NewManager(store, 2, 4)
+}
+
+func TestSubmitSucceeds(t *testing.T) {
+	m := newTestManager(t)
+
+	op, err := m.Submit("team-a", func(ctx context.Context) (interface{}, error) {
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if op.Namespace != "team-a" {
+		t.Errorf("op.Namespace = %q, want %q", op.Namespace, "team-a")
+	}
+
+	final, err := m.Wait(context.Background(), op.ID)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if final.Status != StatusSucceeded {
+		t.Errorf("final.Status = %q, want %q", final.Status, StatusSucceeded)
+	}
+	if string(final.Result) != `"done"` {
+		t.Errorf("final.Result = %s, want %q", final.Result, `"done"`)
+	}
+}
+
+func TestSubmitFails(t *testing.T) {
+	m := newTestManager(t)
+	wantErr := errors.New("boom")
+
+	op, err := m.Submit("team-a", func(ctx context.Context) (interface{}, error) {
+		return nil, wantErr
+	})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+
+	final, err := m.Wait(context.Background(), op.ID)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if final.Status != StatusFailed {
+		t.Errorf("final.Status = %q, want %q", final.Status, StatusFailed)
+	}
+	if final.Error != wantErr.Error() {
+		t.Errorf("final.Error = %q, want %q", final.Error, wantErr.Error())
+	}
+}
+
+func TestCancel(t *testing.T) {
+	m := newTestManager(t)
+	started := make(chan struct{})
+
+	op, err := m.Submit("team-a", func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+
+	<-started
+	if err := m.Cancel(op.ID); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+
+	final, err := m.Wait(context.Background(), op.ID)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if final.Status != StatusCanceled {
+		t.Errorf("final.Status = %q, want %q", final.Status, StatusCanceled)
+	}
+
+	if err := m.Cancel(op.ID); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Cancel on a finished operation returned %v, want %v", err, ErrNotRunning)
+	}
+}
+
+func TestSubmitQueueFull(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "operations.db"))
+	if err != nil {
+		t.Fatalf("OpenStore returned error: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	// No workers draining the queue, so the single slot fills immediately.
+	m := NewManager(store, 0, 1)
+
+	block := make(chan struct{})
+	fn := func(ctx context.Context) (interface{}, error) {
+		<-block
+		return nil, nil
+	}
+
+	if _, err := m.Submit("team-a", fn); err != nil {
+		t.Fatalf("first Submit returned error: %v", err)
+	}
+	if _, err := m.Submit("team-a", fn); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("second Submit returned %v, want %v", err, ErrQueueFull)
+	}
+	close(block)
+}
+
+func TestRunning(t *testing.T) {
+	m := newTestManager(t)
+	release := make(chan struct{})
+
+	op, err := m.Submit("team-a", func(ctx context.Context) (interface{}, error) {
+		<-release
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if got := m.Running(); got != 1 {
+		t.Errorf("Running() = %d, want 1", got)
+	}
+
+	close(release)
+	if _, err := m.Wait(context.Background(), op.ID); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if got := m.Running(); got != 0 {
+		t.Errorf("Running() = %d after completion, want 0", got)
+	}
+}
+
+func TestNewManagerReconcilesStaleRunningOperations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "operations.db")
+
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore returned error: %v", err)
+	}
+	stale := &Operation{ID: "stale-op", Namespace: "team-a", Status: StatusRunning, UpdatedAt: time.Now()}
+	if err := store.Put(stale); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	store.Close()
+
+	store, err = OpenStore(path)
+	if err != nil {
+		t.Fatalf("re-OpenStore returned error: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	NewManager(store, 1, 1)
+
+	reconciled, err := store.Get("stale-op")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if reconciled.Status != StatusFailed {
+		t.Errorf("reconciled.Status = %q, want %q", reconciled.Status, StatusFailed)
+	}
+}
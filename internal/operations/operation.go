@@ -0,0 +1,42 @@
+// Package operations models long-running mutating calls (VM create, resize,
+// attach, delete, ...) as pollable Operation resources, similar to the
+// operations API used by LXD and Podman, instead of blocking the HTTP
+// request on the underlying Crossplane apply and readiness wait.
+package operations
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status is the terminal or in-progress state of an Operation.
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+)
+
+// Operation is the JSON representation returned to clients and persisted
+// across restarts.
+type Operation struct {
+	ID        string          `json:"operation_id"`
+	Namespace string          `json:"namespace"`
+	Status    Status          `json:"status"`
+	Self      string          `json:"self"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Done reports whether the operation has reached a terminal state.
+func (o *Operation) Done() bool {
+	return o.Status != StatusRunning
+}
+
+func marshalResult(result interface{}) (json.RawMessage, error) {
+	return json.Marshal(result)
+}
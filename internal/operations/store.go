@@ -0,0 +1,97 @@
+package operations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrNotFound is returned by Store.Get when no operation with the given ID
+// has been persisted.
+var ErrNotFound = errors.New("operation not found")
+
+var operationsBucket = []byte("operations")
+
+// Store persists Operation metadata in BoltDB, so a restart doesn't lose
+// track of in-flight or recently finished operations.
+type Store struct {
+	db *bolt.DB
+}
+
+// OpenStore opens (creating if necessary) a BoltDB file at path.
+func OpenStore(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open operations store %q: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(operationsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize operations bucket: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put persists op, overwriting any previous record with the same ID.
+func (s *Store) Put(op *Operation) error {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("failed to marshal operation %q: %w", op.ID, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(operationsBucket).Put([]byte(op.ID), data)
+	})
+}
+
+// Get loads the operation with the given ID, or ErrNotFound.
+func (s *Store) Get(id string) (*Operation, error) {
+	var op Operation
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(operationsBucket).Get([]byte(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &op)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// ListRunning returns every persisted operation still in StatusRunning, so
+// a fresh Manager can reconcile operations left running by a prior process
+// that crashed or restarted before they reached a terminal state.
+func (s *Store) ListRunning() ([]*Operation, error) {
+	var running []*Operation
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(operationsBucket).ForEach(func(_, data []byte) error {
+			var op Operation
+			if err := json.Unmarshal(data, &op); err != nil {
+				return err
+			}
+			if op.Status == StatusRunning {
+				running = append(running, &op)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return running, nil
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Atmajyoti208/crossplane-go/internal/crossplane"
+)
+
+// awsProvider provisions VMs through the upbound-provider-aws
+// Instance/Volume/VolumeAttachment CRs.
+type awsProvider struct{}
+
+func (p *awsProvider) Name() Name { return AWS }
+
+func (p *awsProvider) InstanceGVR() schema.GroupVersionResource { return crossplane.AWSInstanceGVR }
+func (p *awsProvider) VolumeGVR() schema.GroupVersionResource   { return crossplane.AWSVolumeGVR }
+func (p *awsProvider) AttachmentGVR() schema.GroupVersionResource {
+	return crossplane.AWSVolumeAttachmentGVR
+}
+
+// CreateVM maps the provider-agnostic VMSpec onto EC2 terms: ImageID is an
+// AMI, FlavorID an instance type, NetworkID a subnet.
+func (p *awsProvider) CreateVM(spec VMSpec) *unstructured.Unstructured {
+	return crossplane.NewAWSInstance(crossplane.AWSInstanceSpec{
+		Name:             spec.Name,
+		Namespace:        spec.Namespace,
+		AMI:              spec.ImageID,
+		InstanceType:     spec.FlavorID,
+		SubnetID:         spec.NetworkID,
+		SecurityGroupIDs: spec.SecurityGroups,
+	})
+}
+
+func (p *awsProvider) Resize(instance *unstructured.Unstructured, flavorID string) error {
+	if err := unstructured.SetNestedField(instance.Object, flavorID, "spec", "forProvider", "instanceType"); err != nil {
+		return fmt.Errorf("failed to set instanceType: %w", err)
+	}
+	return nil
+}
+
+// SetPowerState is unsupported: the upbound-provider-aws Instance CR has no
+// declarative power-state field, so there's no forProvider key to map
+// "Running"/"Shutoff" onto.
+func (p *awsProvider) SetPowerState(instance *unstructured.Unstructured, state string) error {
+	return fmt.Errorf("power state control is not supported for provider %q", p.Name())
+}
+
+// CreateVolume maps VolumeSpec.Size (GB) directly onto EBS volume size.
+func (p *awsProvider) CreateVolume(spec VolumeSpec) *unstructured.Unstructured {
+	return crossplane.NewAWSVolume(crossplane.AWSVolumeSpec{
+		Name:             spec.Name,
+		Namespace:        spec.Namespace,
+		Size:             spec.Size,
+		AvailabilityZone: spec.Zone,
+	})
+}
+
+func (p *awsProvider) AttachVolume(spec AttachmentSpec) *unstructured.Unstructured {
+	return crossplane.NewAWSVolumeAttachment(crossplane.AWSVolumeAttachmentSpec{
+		Name:       spec.Name,
+		Namespace:  spec.Namespace,
+		InstanceID: spec.InstanceID,
+		VolumeID:   spec.VolumeID,
+	})
+}
+
+func (p *awsProvider) Delete(ctx context.Context, namespace, name string) error {
+	return nil
+}
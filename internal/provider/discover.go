@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/client-go/discovery"
+)
+
+// apiGroup is the Crossplane provider API group that must be present in the
+// cluster's discovery document for a Provider to be usable.
+var apiGroup = map[Name]string{
+	OpenStack: "compute.openstack.crossplane.io",
+	AWS:       "ec2.aws.upbound.io",
+	GCP:       "compute.gcp.upbound.io",
+}
+
+// Discover reports which of the registered providers have their Crossplane
+// provider CRDs installed in the cluster, as seen via disc's server groups.
+func Discover(disc discovery.DiscoveryInterface) ([]Name, error) {
+	groups, err := disc.ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API groups: %w", err)
+	}
+
+	installed := make(map[string]bool, len(groups.Groups))
+	for _, g := range groups.Groups {
+		installed[g.Name] = true
+	}
+
+	var available []Name
+	for name := range registry {
+		if installed[apiGroup[name]] {
+			available = append(available, name)
+		}
+	}
+	sort.Slice(available, func(i, j int) bool { return available[i] < available[j] })
+	return available, nil
+}
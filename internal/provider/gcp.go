@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Atmajyoti208/crossplane-go/internal/crossplane"
+)
+
+// gcpProvider provisions VMs through the upbound-provider-gcp
+// Instance/Disk/AttachedDisk CRs.
+type gcpProvider struct{}
+
+func (p *gcpProvider) Name() Name { return GCP }
+
+func (p *gcpProvider) InstanceGVR() schema.GroupVersionResource { return crossplane.GCPInstanceGVR }
+func (p *gcpProvider) VolumeGVR() schema.GroupVersionResource   { return crossplane.GCPDiskGVR }
+func (p *gcpProvider) AttachmentGVR() schema.GroupVersionResource {
+	return crossplane.GCPAttachedDiskGVR
+}
+
+// CreateVM maps the provider-agnostic VMSpec onto Compute Engine terms:
+// ImageID is a boot disk image, FlavorID a machine type, NetworkID a
+// network self-link.
+func (p *gcpProvider) CreateVM(spec VMSpec) *unstructured.Unstructured {
+	return crossplane.NewGCPInstance(crossplane.GCPInstanceSpec{
+		Name:        spec.Name,
+		Namespace:   spec.Namespace,
+		Zone:        spec.Zone,
+		MachineType: spec.FlavorID,
+		Image:       spec.ImageID,
+		Network:     spec.NetworkID,
+	})
+}
+
+func (p *gcpProvider) Resize(instance *unstructured.Unstructured, flavorID string) error {
+	if err := unstructured.SetNestedField(instance.Object, flavorID, "spec", "forProvider", "machineType"); err != nil {
+		return fmt.Errorf("failed to set machineType: %w", err)
+	}
+	return nil
+}
+
+// SetPowerState is unsupported: the upbound-provider-gcp Instance CR has no
+// declarative power-state field, so there's no forProvider key to map
+// "Running"/"Shutoff" onto.
+func (p *gcpProvider) SetPowerState(instance *unstructured.Unstructured, state string) error {
+	return fmt.Errorf("power state control is not supported for provider %q", p.Name())
+}
+
+// CreateVolume maps VolumeSpec.Size (GB) directly onto persistent disk size.
+func (p *gcpProvider) CreateVolume(spec VolumeSpec) *unstructured.Unstructured {
+	return crossplane.NewGCPDisk(crossplane.GCPDiskSpec{
+		Name:      spec.Name,
+		Namespace: spec.Namespace,
+		Zone:      spec.Zone,
+		SizeGB:    spec.Size,
+	})
+}
+
+func (p *gcpProvider) AttachVolume(spec AttachmentSpec) *unstructured.Unstructured {
+	return crossplane.NewGCPAttachedDisk(crossplane.GCPAttachedDiskSpec{
+		Name:      spec.Name,
+		Namespace: spec.Namespace,
+		Zone:      spec.Zone,
+		Instance:  spec.InstanceID,
+		Disk:      spec.VolumeID,
+	})
+}
+
+func (p *gcpProvider) Delete(ctx context.Context, namespace, name string) error {
+	return nil
+}
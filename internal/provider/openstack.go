@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Atmajyoti208/crossplane-go/internal/crossplane"
+)
+
+// openStackProvider provisions VMs through the Crossplane OpenStack
+// provider's InstanceV2/VolumeV3/VolumeAttachmentV2 CRs.
+type openStackProvider struct{}
+
+func (p *openStackProvider) Name() Name { return OpenStack }
+
+func (p *openStackProvider) InstanceGVR() schema.GroupVersionResource {
+	return crossplane.InstanceV2GVR
+}
+func (p *openStackProvider) VolumeGVR() schema.GroupVersionResource { return crossplane.VolumeV3GVR }
+func (p *openStackProvider) AttachmentGVR() schema.GroupVersionResource {
+	return crossplane.VolumeAttachmentV2GVR
+}
+
+func (p *openStackProvider) CreateVM(spec VMSpec) *unstructured.Unstructured {
+	return crossplane.NewInstanceV2(crossplane.InstanceSpec{
+		Name:           spec.Name,
+		Namespace:      spec.Namespace,
+		ImageID:        spec.ImageID,
+		FlavorID:       spec.FlavorID,
+		NetworkID:      spec.NetworkID,
+		SecurityGroups: spec.SecurityGroups,
+	})
+}
+
+func (p *openStackProvider) Resize(instance *unstructured.Unstructured, flavorID string) error {
+	if err := unstructured.SetNestedField(instance.Object, flavorID, "spec", "forProvider", "flavorId"); err != nil {
+		return fmt.Errorf("failed to set flavorId: %w", err)
+	}
+	return nil
+}
+
+func (p *openStackProvider) SetPowerState(instance *unstructured.Unstructured, state string) error {
+	if err := unstructured.SetNestedField(instance.Object, state, "spec", "forProvider", "powerState"); err != nil {
+		return fmt.Errorf("failed to set powerState: %w", err)
+	}
+	return nil
+}
+
+func (p *openStackProvider) CreateVolume(spec VolumeSpec) *unstructured.Unstructured {
+	return crossplane.NewVolumeV3(crossplane.VolumeSpec{
+		Name:        spec.Name,
+		Namespace:   spec.Namespace,
+		Size:        spec.Size,
+		Description: spec.Description,
+	})
+}
+
+func (p *openStackProvider) AttachVolume(spec AttachmentSpec) *unstructured.Unstructured {
+	return crossplane.NewVolumeAttachmentV2(crossplane.AttachmentSpec{
+		Name:       spec.Name,
+		Namespace:  spec.Namespace,
+		InstanceID: spec.InstanceID,
+		VolumeID:   spec.VolumeID,
+	})
+}
+
+func (p *openStackProvider) Delete(ctx context.Context, namespace, name string) error {
+	return nil
+}
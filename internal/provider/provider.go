@@ -0,0 +1,116 @@
+// Package provider generalizes VM/volume provisioning across the
+// Crossplane providers this API server supports, so handlers build the
+// right managed resource without hard-coding OpenStack field names.
+package provider
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Name identifies a supported infrastructure provider.
+type Name string
+
+const (
+	OpenStack Name = "openstack"
+	AWS       Name = "aws"
+	GCP       Name = "gcp"
+)
+
+// Default is the provider used when a team specifies none.
+const Default = OpenStack
+
+// VMSpec describes a virtual machine to provision, independent of the
+// backing provider. Zone is the availability zone/zone to provision into;
+// providers that don't need one (OpenStack, AWS) ignore it.
+type VMSpec struct {
+	Name           string
+	Namespace      string
+	Zone           string
+	ImageID        string
+	FlavorID       string
+	NetworkID      string
+	SecurityGroups []string
+}
+
+// VolumeSpec describes a block volume to provision. Zone is the
+// availability zone/zone to provision into; providers that don't need one
+// (OpenStack) ignore it.
+type VolumeSpec struct {
+	Name        string
+	Namespace   string
+	Zone        string
+	Size        int
+	Description string
+}
+
+// AttachmentSpec describes a volume-to-instance attachment. Zone is the
+// availability zone/zone the attachment happens in; providers that don't
+// need one (OpenStack, AWS) ignore it.
+type AttachmentSpec struct {
+	Name       string
+	Namespace  string
+	Zone       string
+	InstanceID string
+	VolumeID   string
+}
+
+// Provider builds the Crossplane managed resources for a specific cloud.
+// Apply, readiness-wait, and deletion against the Kubernetes API are left
+// to the caller, which already knows how to drive any GVR generically.
+type Provider interface {
+	// Name reports which provider this implementation is.
+	Name() Name
+
+	// InstanceGVR, VolumeGVR, and AttachmentGVR report the managed resource
+	// this provider uses for each resource kind.
+	InstanceGVR() schema.GroupVersionResource
+	VolumeGVR() schema.GroupVersionResource
+	AttachmentGVR() schema.GroupVersionResource
+
+	// CreateVM builds the unstructured object for a new VM.
+	CreateVM(spec VMSpec) *unstructured.Unstructured
+	// Resize mutates instance in place to request flavorID, using
+	// whatever field this provider's managed resource exposes for it.
+	Resize(instance *unstructured.Unstructured, flavorID string) error
+	// SetPowerState mutates instance in place to request the given power
+	// state ("Running"/"Shutoff"), using whatever field this provider's
+	// managed resource exposes for it. Providers without a declarative
+	// power-state field return an error instead of guessing one.
+	SetPowerState(instance *unstructured.Unstructured, state string) error
+	// CreateVolume builds the unstructured object for a new block volume.
+	CreateVolume(spec VolumeSpec) *unstructured.Unstructured
+	// AttachVolume builds the unstructured object that attaches a volume
+	// to an instance.
+	AttachVolume(spec AttachmentSpec) *unstructured.Unstructured
+	// Delete runs any provider-specific pre-deletion step for name before
+	// the caller deletes the underlying managed resource.
+	Delete(ctx context.Context, namespace, name string) error
+}
+
+// Select returns the Provider registered under name.
+func Select(name Name) (Provider, error) {
+	p, ok := registry[name]
+	if !ok {
+		return nil, &UnknownProviderError{Name: name}
+	}
+	return p, nil
+}
+
+// UnknownProviderError reports that name does not match any registered
+// Provider.
+type UnknownProviderError struct {
+	Name Name
+}
+
+func (e *UnknownProviderError) Error() string {
+	return "unknown provider: " + string(e.Name)
+}
+
+var registry = map[Name]Provider{
+	OpenStack: &openStackProvider{},
+	AWS:       &awsProvider{},
+	GCP:       &gcpProvider{},
+}
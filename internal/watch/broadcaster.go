@@ -0,0 +1,49 @@
+package watch
+
+import "sync"
+
+// eventBuffer is how many events a slow subscriber may lag behind before
+// events are dropped for it, to keep a stuck SSE client from blocking the
+// informer's event handlers.
+const eventBuffer = 32
+
+// broadcaster fans out Events published to it to any number of subscriber
+// channels.
+type broadcaster struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{subscribers: make(map[chan Event]struct{})}
+}
+
+func (b *broadcaster) subscribe() chan Event {
+	ch := make(chan Event, eventBuffer)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broadcaster) unsubscribe(ch chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (b *broadcaster) publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			// Subscriber is lagging; drop the event rather than block
+			// the informer's event handler goroutine.
+		}
+	}
+}
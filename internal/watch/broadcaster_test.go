@@ -0,0 +1,57 @@
+package watch
+
+import "testing"
+
+func TestBroadcasterPublishFansOutToAllSubscribers(t *testing.T) {
+	b := newBroadcaster()
+	ch1 := b.subscribe()
+	ch2 := b.subscribe()
+
+	b.publish(Event{Type: EventAdded, Name: "vm-1"})
+
+	for _, ch := range []chan Event{ch1, ch2} {
+		select {
+		case e := <-ch:
+			if e.Name != "vm-1" {
+				t.Errorf("got event for %q, want %q", e.Name, "vm-1")
+			}
+		default:
+			t.Error("subscriber did not receive published event")
+		}
+	}
+}
+
+func TestBroadcasterUnsubscribeClosesChannelAndStopsDelivery(t *testing.T) {
+	b := newBroadcaster()
+	ch := b.subscribe()
+
+	b.unsubscribe(ch)
+
+	if _, ok := <-ch; ok {
+		t.Error("channel was not closed after unsubscribe")
+	}
+
+	// Publishing after the only subscriber left must not panic or block.
+	b.publish(Event{Type: EventAdded, Name: "vm-1"})
+}
+
+func TestBroadcasterUnsubscribeIsIdempotent(t *testing.T) {
+	b := newBroadcaster()
+	ch := b.subscribe()
+
+	b.unsubscribe(ch)
+	b.unsubscribe(ch) // must not double-close and panic
+}
+
+func TestBroadcasterDropsEventsForLaggingSubscriber(t *testing.T) {
+	b := newBroadcaster()
+	ch := b.subscribe()
+
+	for i := 0; i < eventBuffer+10; i++ {
+		b.publish(Event{Type: EventAdded, Name: "vm-1"})
+	}
+
+	if got := len(ch); got != eventBuffer {
+		t.Errorf("len(ch) = %d, want %d (buffer full, excess dropped)", got, eventBuffer)
+	}
+}
@@ -0,0 +1,185 @@
+// Package watch caches Crossplane managed resources per namespace using
+// shared informers, so handlers can read the latest known status without
+// hitting the API server on every request, and can subscribe to live
+// add/update/delete events for a namespace.
+package watch
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/Atmajyoti208/crossplane-go/internal/crossplane"
+)
+
+// watchedGVRs are the Crossplane managed resources the API server tracks,
+// across every provider it can provision through.
+var watchedGVRs = []schema.GroupVersionResource{
+	crossplane.InstanceV2GVR,
+	crossplane.VolumeV3GVR,
+	crossplane.VolumeAttachmentV2GVR,
+	crossplane.AWSInstanceGVR,
+	crossplane.AWSVolumeGVR,
+	crossplane.AWSVolumeAttachmentGVR,
+	crossplane.GCPInstanceGVR,
+	crossplane.GCPDiskGVR,
+	crossplane.GCPAttachedDiskGVR,
+}
+
+const resyncPeriod = 0
+
+var errNotUnstructured = errors.New("cached object is not unstructured")
+
+// EventType identifies what happened to an object.
+type EventType string
+
+const (
+	EventAdded    EventType = "ADDED"
+	EventModified EventType = "MODIFIED"
+	EventDeleted  EventType = "DELETED"
+)
+
+// Event describes a single change to a watched object, as pushed to SSE
+// subscribers.
+type Event struct {
+	Type      EventType                  `json:"type"`
+	Kind      string                     `json:"kind"`
+	Namespace string                     `json:"namespace"`
+	Name      string                     `json:"name"`
+	Object    *unstructured.Unstructured `json:"object,omitempty"`
+}
+
+// Cache lazily starts one shared informer factory per namespace the API
+// server is asked about, and serves reads from the resulting local store.
+type Cache struct {
+	dynamicClient dynamic.Interface
+
+	mu         sync.Mutex
+	namespaces map[string]*namespaceWatch
+}
+
+type namespaceWatch struct {
+	factory     dynamicinformer.DynamicSharedInformerFactory
+	informers   map[schema.GroupVersionResource]cache.SharedIndexInformer
+	broadcaster *broadcaster
+
+	// ready is closed once this namespace's informers have synced, so
+	// callers only block on the one namespace they asked about.
+	ready chan struct{}
+}
+
+// NewCache builds a Cache that lists/watches through dynamicClient.
+func NewCache(dynamicClient dynamic.Interface) *Cache {
+	return &Cache{
+		dynamicClient: dynamicClient,
+		namespaces:    make(map[string]*namespaceWatch),
+	}
+}
+
+// ensureNamespace starts (once) the informers for namespace and returns the
+// resulting namespaceWatch, blocking until its cache has synced. The global
+// lock is only held long enough to register the namespaceWatch, so a
+// namespace whose informers are slow to sync (or never do, e.g. missing
+// RBAC) only blocks callers asking about that namespace, not every other
+// team's reads.
+func (c *Cache) ensureNamespace(namespace string) *namespaceWatch {
+	c.mu.Lock()
+	nw, ok := c.namespaces[namespace]
+	if ok {
+		c.mu.Unlock()
+		<-nw.ready
+		return nw
+	}
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(c.dynamicClient, resyncPeriod, namespace, nil)
+	nw = &namespaceWatch{
+		factory:     factory,
+		informers:   make(map[schema.GroupVersionResource]cache.SharedIndexInformer),
+		broadcaster: newBroadcaster(),
+		ready:       make(chan struct{}),
+	}
+
+	for _, gvr := range watchedGVRs {
+		informer := factory.ForResource(gvr).Informer()
+		gvr := gvr
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { nw.broadcaster.publish(toEvent(EventAdded, gvr, obj)) },
+			UpdateFunc: func(_, obj interface{}) { nw.broadcaster.publish(toEvent(EventModified, gvr, obj)) },
+			DeleteFunc: func(obj interface{}) { nw.broadcaster.publish(toEvent(EventDeleted, gvr, obj)) },
+		})
+		nw.informers[gvr] = informer
+	}
+
+	c.namespaces[namespace] = nw
+	c.mu.Unlock()
+
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	go func() {
+		factory.WaitForCacheSync(stopCh)
+		close(nw.ready)
+	}()
+
+	<-nw.ready
+	return nw
+}
+
+func toEvent(t EventType, gvr schema.GroupVersionResource, obj interface{}) Event {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return Event{Type: t}
+	}
+	return Event{
+		Type:      t,
+		Kind:      u.GetKind(),
+		Namespace: u.GetNamespace(),
+		Name:      u.GetName(),
+		Object:    u,
+	}
+}
+
+// GetInstance returns the cached InstanceV2 object for name in namespace.
+// The second return value is false if the object isn't known to the cache.
+func (c *Cache) GetInstance(namespace, name string) (*unstructured.Unstructured, bool, error) {
+	return c.GetObject(crossplane.InstanceV2GVR, namespace, name)
+}
+
+// GetObject returns the cached object of the given GVR for name in
+// namespace. The second return value is false if the object isn't known to
+// the cache.
+func (c *Cache) GetObject(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, bool, error) {
+	nw := c.ensureNamespace(namespace)
+	informer, ok := nw.informers[gvr]
+	if !ok {
+		return nil, false, fmt.Errorf("gvr %s is not watched by this cache", gvr)
+	}
+
+	obj, exists, err := informer.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, false, err
+	}
+	if !exists {
+		return nil, false, nil
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, false, apierrors.NewInternalError(errNotUnstructured)
+	}
+	return u, true, nil
+}
+
+// Subscribe registers a new listener for events in namespace. Callers must
+// invoke the returned cancel func once done to avoid leaking the channel.
+func (c *Cache) Subscribe(namespace string) (<-chan Event, func()) {
+	nw := c.ensureNamespace(namespace)
+	ch := nw.broadcaster.subscribe()
+	return ch, func() { nw.broadcaster.unsubscribe(ch) }
+}
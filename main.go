@@ -1,50 +1,217 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"net/http"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
-	"gopkg.in/yaml.v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Atmajyoti208/crossplane-go/internal/auth"
+	"github.com/Atmajyoti208/crossplane-go/internal/blueprint"
+	"github.com/Atmajyoti208/crossplane-go/internal/crossplane"
+	"github.com/Atmajyoti208/crossplane-go/internal/k8s"
+	"github.com/Atmajyoti208/crossplane-go/internal/logging"
+	"github.com/Atmajyoti208/crossplane-go/internal/metrics"
+	"github.com/Atmajyoti208/crossplane-go/internal/operations"
+	"github.com/Atmajyoti208/crossplane-go/internal/provider"
+	"github.com/Atmajyoti208/crossplane-go/internal/watch"
 )
 
-// applyYAML is a utility function to apply YAML using kubectl
-func applyYAML(yamlContent interface{}, filename string) error {
-	data, err := yaml.Marshal(yamlContent)
+const (
+	defaultOperationsDBPath = "operations.db"
+	operationWorkers        = 4
+	operationQueueSize      = 64
+	defaultWaitTimeout      = 30 * time.Second
+	readyPollInterval       = 2 * time.Second
+	// readyTimeout bounds waitForReady, independent of any client-side
+	// /wait?timeout=.
+	readyTimeout = 15 * time.Minute
+)
+
+// namespacesGVR and deploymentsGVR let the authorizer evaluate requests
+// against plain Kubernetes resources alongside the Crossplane GVRs.
+var (
+	namespacesGVR  = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	deploymentsGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+// teamProviderAnnotation names the namespace annotation that records the
+// infrastructure provider a team provisions through by default.
+const teamProviderAnnotation = "crossplane-go.io/provider"
+
+// resolveProvider picks the Provider used for namespace: the "provider"
+// query parameter if set, else the namespace's recorded default, else
+// provider.Default.
+func (s *apiServer) resolveProvider(r *http.Request, namespace string) (provider.Provider, error) {
+	name := provider.Name(r.URL.Query().Get("provider"))
+	if name == "" {
+		ns, err := s.clients.Typed.CoreV1().Namespaces().Get(r.Context(), namespace, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up provider for team %q: %w", namespace, err)
+		}
+		name = provider.Name(ns.Annotations[teamProviderAnnotation])
+	}
+	if name == "" {
+		name = provider.Default
+	}
+	return provider.Select(name)
+}
+
+// apiServer holds the Kubernetes clients shared by all handlers.
+type apiServer struct {
+	clients    *k8s.Clients
+	watchCache *watch.Cache
+	ops        *operations.Manager
+	authorizer *auth.Authorizer
+	logger     logr.Logger
+}
+
+// checkNamespaceAccess reports whether the caller may "get" the named team
+// namespace, writing a 401/403 response and returning false otherwise. Call
+// this before resolveProvider or any other lookup keyed on a caller-supplied
+// team ID: resolving a provider or reading the namespace itself leaks
+// whether the namespace exists and which provider it uses, so that lookup
+// must not happen for a caller with no RBAC on the team at all.
+func (s *apiServer) checkNamespaceAccess(w http.ResponseWriter, r *http.Request, namespace string) bool {
+	return s.checkAuthorized(w, r, "get", namespacesGVR, "", namespace)
+}
+
+// checkAuthorized reports whether the request's authenticated caller may
+// perform verb against the given resource, writing a 401/403 response and
+// returning false otherwise.
+func (s *apiServer) checkAuthorized(w http.ResponseWriter, r *http.Request, verb string, gvr schema.GroupVersionResource, namespace, name string) bool {
+	info, ok := auth.UserFrom(r.Context())
+	if !ok {
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return false
+	}
+
+	allowed, err := s.authorizer.Authorize(r.Context(), info, verb, gvr, namespace, name)
 	if err != nil {
-		return fmt.Errorf("failed to marshal YAML: %w", err)
+		writeError(w, fmt.Errorf("authorization check failed: %w", err), http.StatusInternalServerError)
+		return false
 	}
+	if !allowed {
+		http.Error(w, fmt.Sprintf("forbidden: user '%s' cannot %s %s in namespace '%s'", info.GetName(), verb, gvr.Resource, namespace), http.StatusForbidden)
+		return false
+	}
+	return true
+}
 
-	err = ioutil.WriteFile(filename, data, 0644)
+// authorizedClients checks authorization like checkAuthorized, then builds
+// a client impersonating the caller, so Kubernetes RBAC is evaluated
+// against the original requester rather than this server's own identity.
+func (s *apiServer) authorizedClients(w http.ResponseWriter, r *http.Request, verb string, gvr schema.GroupVersionResource, namespace, name string) (*k8s.Clients, bool) {
+	if !s.checkAuthorized(w, r, verb, gvr, namespace, name) {
+		return nil, false
+	}
+
+	info, _ := auth.UserFrom(r.Context())
+	clients, err := s.clients.Impersonate(info)
 	if err != nil {
-		return fmt.Errorf("failed to write YAML to file: %w", err)
+		writeError(w, fmt.Errorf("failed to build impersonated client: %w", err), http.StatusInternalServerError)
+		return nil, false
+	}
+	return clients, true
+}
+
+// waitForReady polls the cache until the object's Ready condition is True,
+// ctx is done, or readyTimeout elapses — whichever comes first, so a
+// resource stuck in Creating can't tie up a worker (and eventually the
+// whole operations queue) forever.
+func waitForReady(ctx context.Context, cache *watch.Cache, gvr schema.GroupVersionResource, namespace, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		obj, ok, err := cache.GetObject(gvr, namespace, name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+			if conditionStatus(conditions, "Ready") == "True" {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
 	}
+}
+
+// writeOperationAccepted responds 202 Accepted with the running operation,
+// per the async operations API.
+func writeOperationAccepted(w http.ResponseWriter, op *operations.Operation) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(op)
+}
+
+// apiError is the JSON body returned for any non-2xx response.
+type apiError struct {
+	Error string `json:"error"`
+}
 
-	cmd := exec.Command("kubectl", "apply", "-f", filename)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to execute kubectl apply: %w", err)
+// writeError maps err to an HTTP status code, using apierrors to recognize
+// Kubernetes API responses, and writes it as a typed JSON error body.
+func writeError(w http.ResponseWriter, err error, fallback int) {
+	status := fallback
+	switch {
+	case apierrors.IsNotFound(err):
+		status = http.StatusNotFound
+	case apierrors.IsConflict(err):
+		status = http.StatusConflict
+	case apierrors.IsAlreadyExists(err):
+		status = http.StatusConflict
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		status = http.StatusBadRequest
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		status = http.StatusForbidden
 	}
-	return nil
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(apiError{Error: err.Error()})
 }
 
 // Health check
-func hello(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) hello(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, "Crossplane OpenStack API is running.")
 }
 
+// listProviders reports which supported infrastructure providers have
+// their Crossplane CRDs installed in the cluster.
+func (s *apiServer) listProviders(w http.ResponseWriter, r *http.Request) {
+	available, err := provider.Discover(s.clients.Discovery)
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to discover providers: %w", err), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"providers": available})
+}
+
 // Register team (namespace)
-func registerTeam(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) registerTeam(w http.ResponseWriter, r *http.Request) {
 	var data struct {
-		Name string `json:"name"`
+		Name     string `json:"name"`
+		Provider string `json:"provider"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		http.Error(w, fmt.Sprintf("Error decoding request: %v", err), http.StatusBadRequest)
@@ -56,19 +223,24 @@ func registerTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	namespaceYAML := map[string]interface{}{
-		"apiVersion": "v1",
-		"kind":       "Namespace",
-		"metadata": map[string]string{
-			"name": data.Name,
-		},
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: data.Name},
+	}
+	if data.Provider != "" {
+		if _, err := provider.Select(provider.Name(data.Provider)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ns.Annotations = map[string]string{teamProviderAnnotation: data.Provider}
 	}
 
-	filename := filepath.Join(os.TempDir(), fmt.Sprintf("namespace-%s.yaml", data.Name))
-	defer os.Remove(filename) // Clean up temp file
+	clients, ok := s.authorizedClients(w, r, "create", namespacesGVR, "", data.Name)
+	if !ok {
+		return
+	}
 
-	if err := applyYAML(namespaceYAML, filename); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create namespace: %v", err), http.StatusInternalServerError)
+	if _, err := clients.Typed.CoreV1().Namespaces().Create(r.Context(), ns, metav1.CreateOptions{}); err != nil {
+		writeError(w, fmt.Errorf("failed to create namespace: %w", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -76,38 +248,34 @@ func registerTeam(w http.ResponseWriter, r *http.Request) {
 }
 
 // Get team details
-func getTeam(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) getTeam(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamID := vars["team_id"]
 
-	cmd := exec.Command("kubectl", "get", "namespace", teamID, "-o", "json")
-	var out bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to get namespace details: %v\n%s", err, stderr.String()), http.StatusInternalServerError)
+	clients, ok := s.authorizedClients(w, r, "get", namespacesGVR, "", teamID)
+	if !ok {
 		return
 	}
 
-	var result interface{}
-	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to parse kubectl output: %v", err), http.StatusInternalServerError)
+	ns, err := clients.Typed.CoreV1().Namespaces().Get(r.Context(), teamID, metav1.GetOptions{})
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to get namespace %q: %w", teamID, err), http.StatusInternalServerError)
 		return
 	}
-	json.NewEncoder(w).Encode(result)
+	json.NewEncoder(w).Encode(ns)
 }
 
 // Create VM
-func createVM(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) createVM(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamID := vars["team_id"]
 
 	var data struct {
-		Name          string   `json:"name"`
-		ImageID       string   `json:"imageId"`
-		FlavorID      string   `json:"flavorId"`
-		NetworkID     string   `json:"networkId"`
+		Name           string   `json:"name"`
+		Zone           string   `json:"zone"`
+		ImageID        string   `json:"imageId"`
+		FlavorID       string   `json:"flavorId"`
+		NetworkID      string   `json:"networkId"`
 		SecurityGroups []string `json:"securityGroups"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
@@ -123,39 +291,56 @@ func createVM(w http.ResponseWriter, r *http.Request) {
 		data.SecurityGroups = []string{"default"}
 	}
 
-	instanceYAML := map[string]interface{}{
-		"apiVersion": "compute.openstack.crossplane.io/v1alpha1",
-		"kind":       "InstanceV2",
-		"metadata": map[string]string{
-			"name":      data.Name,
-			"namespace": teamID,
-		},
-		"spec": map[string]interface{}{
-			"forProvider": map[string]interface{}{
-				"configDrive":    true,
-				"flavorId":       data.FlavorID,
-				"imageId":        data.ImageID,
-				"name":           data.Name,
-				"network":        []map[string]string{{"uuid": data.NetworkID}},
-				"securityGroups": data.SecurityGroups,
-			},
-			"providerConfigRef": map[string]string{
-				"name": "provider-openstack-config",
-			},
-		},
+	if !s.checkNamespaceAccess(w, r, teamID) {
+		return
+	}
+
+	p, err := s.resolveProvider(r, teamID)
+	if err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	clients, ok := s.authorizedClients(w, r, "create", p.InstanceGVR(), teamID, data.Name)
+	if !ok {
+		return
 	}
 
-	yamlPath := filepath.Join("/home/ubuntu/crossplane-api", fmt.Sprintf("%s-%s.yaml", teamID, data.Name))
-	if err := applyYAML(instanceYAML, yamlPath); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to provision VM: %v", err), http.StatusInternalServerError)
+	instance := p.CreateVM(provider.VMSpec{
+		Name:           data.Name,
+		Namespace:      teamID,
+		Zone:           data.Zone,
+		ImageID:        data.ImageID,
+		FlavorID:       data.FlavorID,
+		NetworkID:      data.NetworkID,
+		SecurityGroups: data.SecurityGroups,
+	})
+
+	opLog := s.logger.WithValues("team", teamID, "vm", data.Name, "action", "create", "crossplane_gvk", instance.GroupVersionKind().String())
+
+	op, err := s.ops.Submit(teamID, func(ctx context.Context) (interface{}, error) {
+		applied, err := crossplane.Apply(ctx, clients.Dynamic, p.InstanceGVR(), instance)
+		if err != nil {
+			opLog.Error(err, "failed to provision VM")
+			return nil, fmt.Errorf("failed to provision VM: %w", err)
+		}
+		if err := waitForReady(ctx, s.watchCache, p.InstanceGVR(), teamID, data.Name); err != nil {
+			opLog.Error(err, "VM did not become ready")
+			return nil, fmt.Errorf("VM '%s' did not become ready: %w", data.Name, err)
+		}
+		opLog.Info("VM provisioned")
+		return applied, nil
+	})
+	if err != nil {
+		writeError(w, err, http.StatusServiceUnavailable)
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]string{"message": fmt.Sprintf("VM '%s' provisioned successfully in namespace '%s'.", data.Name, teamID)})
+	writeOperationAccepted(w, op)
 }
 
 // Resize VM
-func resizeVM(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) resizeVM(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamName := vars["team_name"]
 	vmName := vars["vm_name"]
@@ -173,46 +358,63 @@ func resizeVM(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	yamlPath := filepath.Join("/home/ubuntu/crossplane-api", fmt.Sprintf("%s-%s.yaml", teamName, vmName))
-	if _, err := os.Stat(yamlPath); os.IsNotExist(err) {
-		http.Error(w, fmt.Sprintf("%s not found", yamlPath), http.StatusNotFound)
+	if !s.checkNamespaceAccess(w, r, teamName) {
 		return
 	}
 
-	fileContent, err := ioutil.ReadFile(yamlPath)
+	p, err := s.resolveProvider(r, teamName)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to read VM YAML: %v", err), http.StatusInternalServerError)
+		writeError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	var instance map[string]interface{}
-	if err := yaml.Unmarshal(fileContent, &instance); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to unmarshal VM YAML: %v", err), http.StatusInternalServerError)
+	clients, ok := s.authorizedClients(w, r, "update", p.InstanceGVR(), teamName, vmName)
+	if !ok {
 		return
 	}
 
-	if spec, ok := instance["spec"].(map[interface{}]interface{}); ok {
-		if forProvider, ok := spec["forProvider"].(map[interface{}]interface{}); ok {
-			forProvider["flavorId"] = data.FlavorID
-		}
+	instance, err := clients.Dynamic.Resource(p.InstanceGVR()).Namespace(teamName).Get(r.Context(), vmName, metav1.GetOptions{})
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to get VM %q: %w", vmName, err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := p.Resize(instance, data.FlavorID); err != nil {
+		writeError(w, err, http.StatusInternalServerError)
+		return
 	}
 
-	if err := applyYAML(instance, yamlPath); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to update VM flavor: %v", err), http.StatusInternalServerError)
+	opLog := s.logger.WithValues("team", teamName, "vm", vmName, "action", "resize", "crossplane_gvk", instance.GroupVersionKind().String())
+
+	op, err := s.ops.Submit(teamName, func(ctx context.Context) (interface{}, error) {
+		applied, err := crossplane.Apply(ctx, clients.Dynamic, p.InstanceGVR(), instance)
+		if err != nil {
+			opLog.Error(err, "failed to update VM flavor")
+			return nil, fmt.Errorf("failed to update VM flavor: %w", err)
+		}
+		if err := waitForReady(ctx, s.watchCache, p.InstanceGVR(), teamName, vmName); err != nil {
+			opLog.Error(err, "VM did not become ready after resize")
+			return nil, fmt.Errorf("VM '%s' did not become ready after resize: %w", vmName, err)
+		}
+		opLog.Info("VM resized")
+		return applied, nil
+	})
+	if err != nil {
+		writeError(w, err, http.StatusServiceUnavailable)
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]string{"message": fmt.Sprintf("Flavor for VM '%s' updated successfully.", vmName)})
+	writeOperationAccepted(w, op)
 }
 
-// Scale VM (Note: This assumes a Kubernetes Deployment/StatefulSet for scaling, not directly a Crossplane InstanceV2 resource)
-func scaleVM(w http.ResponseWriter, r *http.Request) {
+// Scale VM (Note: This assumes a Kubernetes Deployment for scaling, not directly a Crossplane InstanceV2 resource)
+func (s *apiServer) scaleVM(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamID := vars["team_id"]
 	resourceID := vars["resource_id"]
 
 	var data struct {
-		Replicas *int `json:"replicas"` // Use pointer to distinguish between missing and 0
+		Replicas *int32 `json:"replicas"` // Use pointer to distinguish between missing and 0
 	}
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		http.Error(w, fmt.Sprintf("Error decoding request: %v", err), http.StatusBadRequest)
@@ -224,11 +426,20 @@ func scaleVM(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cmd := exec.Command("kubectl", "scale", fmt.Sprintf("deployment/%s", resourceID), fmt.Sprintf("--replicas=%d", *data.Replicas), "-n", teamID)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to scale VM: %v\n%s", err, stderr.String()), http.StatusInternalServerError)
+	clients, ok := s.authorizedClients(w, r, "update", deploymentsGVR, teamID, resourceID)
+	if !ok {
+		return
+	}
+
+	scale, err := clients.Typed.AppsV1().Deployments(teamID).GetScale(r.Context(), resourceID, metav1.GetOptions{})
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to get deployment %q: %w", resourceID, err), http.StatusInternalServerError)
+		return
+	}
+	scale.Spec.Replicas = *data.Replicas
+
+	if _, err := clients.Typed.AppsV1().Deployments(teamID).UpdateScale(r.Context(), resourceID, scale, metav1.UpdateOptions{}); err != nil {
+		writeError(w, fmt.Errorf("failed to scale VM: %w", err), http.StatusInternalServerError)
 		return
 	}
 
@@ -236,7 +447,7 @@ func scaleVM(w http.ResponseWriter, r *http.Request) {
 }
 
 // Attach Disk
-func attachDisk(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) attachDisk(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamName := vars["team_name"]
 	vmName := vars["vm_name"] // This vm_name is used for naming the attachment, not necessarily the actual instance ID.
@@ -244,6 +455,7 @@ func attachDisk(w http.ResponseWriter, r *http.Request) {
 	var data struct {
 		VolumeID   string `json:"volumeId"`
 		InstanceID string `json:"instanceId"`
+		Zone       string `json:"zone"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		http.Error(w, fmt.Sprintf("Error decoding request: %v", err), http.StatusBadRequest)
@@ -255,48 +467,62 @@ func attachDisk(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// In Go, we don't have uuid.uuid4() directly from standard library for simple strings.
-	// You can use a dedicated UUID package or generate a random string.
-	// For simplicity, let's use a combination of current time and some random bytes.
-	// For production, consider a proper UUID library like github.com/google/uuid
-	nameSuffix := fmt.Sprintf("%x", os.Getpid())
-	attachmentName := fmt.Sprintf("%s-attach-%s", vmName, nameSuffix[:8]) // Truncate for brevity
+	attachmentName := fmt.Sprintf("%s-attach-%s", vmName, randSuffix())
+
+	if !s.checkNamespaceAccess(w, r, teamName) {
+		return
+	}
+
+	p, err := s.resolveProvider(r, teamName)
+	if err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
 
-	volumeAttachment := map[string]interface{}{
-		"apiVersion": "compute.openstack.crossplane.io/v1alpha1",
-		"kind":       "VolumeAttachmentV2",
-		"metadata": map[string]string{
-			"name":      attachmentName,
-			"namespace": teamName,
-		},
-		"spec": map[string]interface{}{
-			"instanceId": data.InstanceID,
-			"volumeId":   data.VolumeID,
-			"providerConfigRef": map[string]string{
-				"name": "provider-openstack-config",
-			},
-			"deletionPolicy": "Delete",
-		},
+	clients, ok := s.authorizedClients(w, r, "create", p.AttachmentGVR(), teamName, attachmentName)
+	if !ok {
+		return
 	}
 
-	yamlPath := filepath.Join(os.TempDir(), fmt.Sprintf("%s.yaml", attachmentName))
-	defer os.Remove(yamlPath)
+	attachment := p.AttachVolume(provider.AttachmentSpec{
+		Name:       attachmentName,
+		Namespace:  teamName,
+		Zone:       data.Zone,
+		InstanceID: data.InstanceID,
+		VolumeID:   data.VolumeID,
+	})
 
-	if err := applyYAML(volumeAttachment, yamlPath); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to attach disk: %v", err), http.StatusInternalServerError)
+	opLog := s.logger.WithValues("team", teamName, "vm", vmName, "action", "attach-disk", "crossplane_gvk", attachment.GroupVersionKind().String())
+
+	op, err := s.ops.Submit(teamName, func(ctx context.Context) (interface{}, error) {
+		applied, err := crossplane.Apply(ctx, clients.Dynamic, p.AttachmentGVR(), attachment)
+		if err != nil {
+			opLog.Error(err, "failed to attach disk")
+			return nil, fmt.Errorf("failed to attach disk: %w", err)
+		}
+		if err := waitForReady(ctx, s.watchCache, p.AttachmentGVR(), teamName, attachmentName); err != nil {
+			opLog.Error(err, "disk attachment did not become ready")
+			return nil, fmt.Errorf("disk attachment '%s' did not become ready: %w", attachmentName, err)
+		}
+		opLog.Info("disk attached")
+		return applied, nil
+	})
+	if err != nil {
+		writeError(w, err, http.StatusServiceUnavailable)
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]string{"message": fmt.Sprintf("Disk attachment request sent: %s", attachmentName)})
+	writeOperationAccepted(w, op)
 }
 
 // Create Block Volume
-func createBlockVolume(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) createBlockVolume(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamName := vars["team_name"]
 
 	var data struct {
 		Name        string `json:"name"`
+		Zone        string `json:"zone"`
 		Size        int    `json:"size"`
 		Description string `json:"description"`
 	}
@@ -310,148 +536,590 @@ func createBlockVolume(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	volumeManifest := map[string]interface{}{
-		"apiVersion": "blockstorage.openstack.crossplane.io/v1alpha1",
-		"kind":       "VolumeV3",
-		"metadata": map[string]string{
-			"name":      data.Name,
-			"namespace": teamName,
-		},
-		"spec": map[string]interface{}{
-			"forProvider": map[string]interface{}{
-				"name":        data.Name,
-				"size":        data.Size,
-				"description": data.Description,
-			},
-			"providerConfigRef": map[string]string{
-				"name": "provider-openstack-config",
-			},
-		},
+	if !s.checkNamespaceAccess(w, r, teamName) {
+		return
 	}
 
-	tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("%s-block.yaml", data.Name))
-	defer os.Remove(tmpFile)
-
-	fileContent, err := yaml.Marshal(volumeManifest)
+	p, err := s.resolveProvider(r, teamName)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal volume manifest: %v", err), http.StatusInternalServerError)
+		writeError(w, err, http.StatusBadRequest)
 		return
 	}
-	if err := ioutil.WriteFile(tmpFile, fileContent, 0644); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to write volume manifest to file: %v", err), http.StatusInternalServerError)
+
+	clients, ok := s.authorizedClients(w, r, "create", p.VolumeGVR(), teamName, data.Name)
+	if !ok {
 		return
 	}
 
-	cmd := exec.Command("kubectl", "apply", "-f", tmpFile)
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	volume := p.CreateVolume(provider.VolumeSpec{
+		Name:        data.Name,
+		Namespace:   teamName,
+		Zone:        data.Zone,
+		Size:        data.Size,
+		Description: data.Description,
+	})
+
+	opLog := s.logger.WithValues("team", teamName, "action", "create-volume", "crossplane_gvk", volume.GroupVersionKind().String())
 
-	if err := cmd.Run(); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to apply block volume manifest: %v\nDetails: %s", err, stderr.String()), http.StatusInternalServerError)
+	op, err := s.ops.Submit(teamName, func(ctx context.Context) (interface{}, error) {
+		applied, err := crossplane.Apply(ctx, clients.Dynamic, p.VolumeGVR(), volume)
+		if err != nil {
+			opLog.Error(err, "failed to apply block volume manifest")
+			return nil, fmt.Errorf("failed to apply block volume manifest: %w", err)
+		}
+		if err := waitForReady(ctx, s.watchCache, p.VolumeGVR(), teamName, data.Name); err != nil {
+			opLog.Error(err, "block volume did not become ready")
+			return nil, fmt.Errorf("block volume '%s' did not become ready: %w", data.Name, err)
+		}
+		opLog.Info("block volume created")
+		return applied, nil
+	})
+	if err != nil {
+		writeError(w, err, http.StatusServiceUnavailable)
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]string{
-		"message":        fmt.Sprintf("Block volume '%s' created successfully in namespace '%s'.", data.Name, teamName),
-		"kubectl_output": stdout.String(),
-	})
+	writeOperationAccepted(w, op)
 }
 
 // Start/Stop/Delete VM actions
-func handleVMAction(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) handleVMAction(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamName := vars["team_name"]
 	vmName := vars["vm_name"]
 	action := vars["action"]
-	script := "/home/ubuntu/admin.sh"
-
-	// ---- Check VM task_state ----
-	var statusOut, statusErr bytes.Buffer
-	statusCmd := exec.Command("bash", "-c", fmt.Sprintf("source %s && openstack server show %s -f json", script, vmName))
-	statusCmd.Stdout = &statusOut
-	statusCmd.Stderr = &statusErr
-
-	if err := statusCmd.Run(); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to fetch VM status: %v\n%s", err, statusErr.String()), http.StatusInternalServerError)
-		return
-	}
-
-	var statusData map[string]interface{}
-	if err := json.Unmarshal(statusOut.Bytes(), &statusData); err != nil {
-		http.Error(w, "Failed to parse VM status", http.StatusInternalServerError)
-		return
-	}
 
-	if taskState, ok := statusData["OS-EXT-STS:task_state"].(string); ok && taskState != "" {
-		http.Error(w, fmt.Sprintf("VM is currently busy (task_state: %s). Try again later.", taskState), http.StatusConflict)
+	if !s.checkNamespaceAccess(w, r, teamName) {
 		return
 	}
 
-	// ---- Construct the command ----
-	var cmd *exec.Cmd
+	var powerState string
 	switch action {
 	case "start":
-		cmd = exec.Command("bash", "-c", fmt.Sprintf("source %s && openstack server start %s", script, vmName))
+		powerState = "Running"
 	case "stop":
-		cmd = exec.Command("bash", "-c", fmt.Sprintf("source %s && openstack server stop %s", script, vmName))
+		powerState = "Shutoff"
 	case "delete":
-		cmd = exec.Command("bash", "-c", fmt.Sprintf("source %s && kubectl delete instancev2 %s --namespace %s", script, vmName, teamName))
+		p, err := s.resolveProvider(r, teamName)
+		if err != nil {
+			writeError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		clients, ok := s.authorizedClients(w, r, "delete", p.InstanceGVR(), teamName, vmName)
+		if !ok {
+			return
+		}
+
+		opLog := s.logger.WithValues("team", teamName, "vm", vmName, "action", "delete", "crossplane_gvk", p.InstanceGVR().String())
+
+		op, err := s.ops.Submit(teamName, func(ctx context.Context) (interface{}, error) {
+			if err := p.Delete(ctx, teamName, vmName); err != nil {
+				opLog.Error(err, "failed to delete VM")
+				return nil, fmt.Errorf("failed to delete VM '%s': %w", vmName, err)
+			}
+			if err := clients.Dynamic.Resource(p.InstanceGVR()).Namespace(teamName).Delete(ctx, vmName, metav1.DeleteOptions{}); err != nil {
+				opLog.Error(err, "failed to delete VM")
+				return nil, fmt.Errorf("failed to delete VM '%s': %w", vmName, err)
+			}
+			opLog.Info("VM deleted")
+			return nil, nil
+		})
+		if err != nil {
+			writeError(w, err, http.StatusServiceUnavailable)
+			return
+		}
+		writeOperationAccepted(w, op)
+		return
 	default:
 		http.Error(w, fmt.Sprintf("Unsupported action '%s'.", action), http.StatusBadRequest)
 		return
 	}
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	p, err := s.resolveProvider(r, teamName)
+	if err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
 
-	// ---- Run action command ----
-	if err := cmd.Run(); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to execute action '%s' on VM '%s': %v\n%s", action, vmName, err, stderr.String()), http.StatusInternalServerError)
+	clients, ok := s.authorizedClients(w, r, "update", p.InstanceGVR(), teamName, vmName)
+	if !ok {
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]string{
-		"message": fmt.Sprintf("Action '%s' executed on VM '%s'. Output:\n%s", action, vmName, stdout.String()),
-	})
-}
+	instance, err := clients.Dynamic.Resource(p.InstanceGVR()).Namespace(teamName).Get(r.Context(), vmName, metav1.GetOptions{})
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to fetch VM %q: %w", vmName, err), http.StatusInternalServerError)
+		return
+	}
+	if err := p.SetPowerState(instance, powerState); err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	opLog := s.logger.WithValues("team", teamName, "vm", vmName, "action", action, "crossplane_gvk", instance.GroupVersionKind().String())
 
+	op, err := s.ops.Submit(teamName, func(ctx context.Context) (interface{}, error) {
+		applied, err := crossplane.Apply(ctx, clients.Dynamic, p.InstanceGVR(), instance)
+		if err != nil {
+			opLog.Error(err, "failed to change VM power state")
+			return nil, fmt.Errorf("failed to %s VM '%s': %w", action, vmName, err)
+		}
+		opLog.Info("VM power state changed")
+		return applied, nil
+	})
+	if err != nil {
+		writeError(w, err, http.StatusServiceUnavailable)
+		return
+	}
 
+	writeOperationAccepted(w, op)
+}
 
 // Delete VM directly
-func deleteVM(w http.ResponseWriter, r *http.Request) {
+func (s *apiServer) deleteVM(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	teamID := vars["team_id"]
 	resourceID := vars["resource_id"]
 
-	cmd := exec.Command("kubectl", "delete", "instancev2", resourceID, "-n", teamID)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to delete VM: %v\n%s", err, stderr.String()), http.StatusInternalServerError)
+	if !s.checkNamespaceAccess(w, r, teamID) {
+		return
+	}
+
+	p, err := s.resolveProvider(r, teamID)
+	if err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	clients, ok := s.authorizedClients(w, r, "delete", p.InstanceGVR(), teamID, resourceID)
+	if !ok {
+		return
+	}
+
+	if err := clients.Dynamic.Resource(p.InstanceGVR()).Namespace(teamID).Delete(r.Context(), resourceID, metav1.DeleteOptions{}); err != nil {
+		writeError(w, fmt.Errorf("failed to delete VM: %w", err), http.StatusInternalServerError)
 		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]string{"message": fmt.Sprintf("VM '%s' deleted from team '%s'.", resourceID, teamID)})
 }
 
+// vmStatus returns the latest cached status of a VM's managed resource, so
+// callers can tell whether provisioning actually finished.
+func (s *apiServer) vmStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["team_id"]
+	vmName := vars["vm_name"]
+
+	if !s.checkNamespaceAccess(w, r, teamID) {
+		return
+	}
+
+	p, err := s.resolveProvider(r, teamID)
+	if err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if !s.checkAuthorized(w, r, "get", p.InstanceGVR(), teamID, vmName) {
+		return
+	}
+
+	instance, ok, err := s.watchCache.GetObject(p.InstanceGVR(), teamID, vmName)
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to read VM status: %w", err), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, fmt.Sprintf("VM '%s' not found in team '%s'", vmName, teamID), http.StatusNotFound)
+		return
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(instance.Object, "status", "conditions")
+
+	response := map[string]interface{}{
+		"ready":      conditionStatus(conditions, "Ready"),
+		"synced":     conditionStatus(conditions, "Synced"),
+		"conditions": conditions,
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// conditionStatus returns the status string ("True"/"False"/"Unknown") for
+// the condition of the given type, or "Unknown" if it isn't present.
+func conditionStatus(conditions []interface{}, condType string) string {
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == condType {
+			if status, ok := cond["status"].(string); ok {
+				return status
+			}
+		}
+	}
+	return "Unknown"
+}
+
+// teamEvents streams add/update/delete events for a team's namespace as
+// Server-Sent Events, so a UI can show real provisioning progress.
+func (s *apiServer) teamEvents(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["team_id"]
+
+	if !s.checkNamespaceAccess(w, r, teamID) {
+		return
+	}
+
+	p, err := s.resolveProvider(r, teamID)
+	if err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if !s.checkAuthorized(w, r, "get", p.InstanceGVR(), teamID, "") {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events, cancel := s.watchCache.Subscribe(teamID)
+	defer cancel()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+// registerBlueprint publishes an XRD+Composition pair so teams can claim
+// the stack it defines instead of assembling managed resources by hand.
+func (s *apiServer) registerBlueprint(w http.ResponseWriter, r *http.Request) {
+	var data struct {
+		XRD         map[string]interface{} `json:"xrd"`
+		Composition map[string]interface{} `json:"composition"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(data.XRD) == 0 || len(data.Composition) == 0 {
+		http.Error(w, "Missing 'xrd' or 'composition' in request", http.StatusBadRequest)
+		return
+	}
+
+	xrd := &unstructured.Unstructured{Object: data.XRD}
+	composition := &unstructured.Unstructured{Object: data.Composition}
+
+	clients, ok := s.authorizedClients(w, r, "create", blueprint.XRDGVR, "", xrd.GetName())
+	if !ok {
+		return
+	}
+
+	appliedXRD, err := crossplane.Apply(r.Context(), clients.Dynamic, blueprint.XRDGVR, xrd)
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to register blueprint XRD: %w", err), http.StatusInternalServerError)
+		return
+	}
+	if _, err := crossplane.Apply(r.Context(), clients.Dynamic, blueprint.CompositionGVR, composition); err != nil {
+		writeError(w, fmt.Errorf("failed to register blueprint composition: %w", err), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(appliedXRD)
+}
+
+// listBlueprints reports every published blueprint, discovered live via the
+// dynamic client rather than a separate registry.
+func (s *apiServer) listBlueprints(w http.ResponseWriter, r *http.Request) {
+	clients, ok := s.authorizedClients(w, r, "list", blueprint.XRDGVR, "", "")
+	if !ok {
+		return
+	}
+
+	xrds, err := blueprint.ListXRDs(r.Context(), clients.Dynamic)
+	if err != nil {
+		writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"blueprints": xrds})
+}
+
+// createClaim creates a Claim against the named blueprint in the team's
+// namespace, merging the caller's parameters into spec.parameters.
+func (s *apiServer) createClaim(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["team_id"]
+	blueprintName := vars["blueprint"]
+
+	var data struct {
+		Name       string                 `json:"name"`
+		Parameters map[string]interface{} `json:"parameters"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if data.Name == "" {
+		http.Error(w, "Missing 'name' in request", http.StatusBadRequest)
+		return
+	}
+
+	xrd, err := s.clients.Dynamic.Resource(blueprint.XRDGVR).Get(r.Context(), blueprintName, metav1.GetOptions{})
+	if err != nil {
+		writeError(w, fmt.Errorf("failed to look up blueprint %q: %w", blueprintName, err), http.StatusNotFound)
+		return
+	}
+	claimGVR, err := blueprint.ClaimGVR(xrd)
+	if err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
+	claimKind, err := blueprint.ClaimKind(xrd)
+	if err != nil {
+		writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	clients, ok := s.authorizedClients(w, r, "create", claimGVR, teamID, data.Name)
+	if !ok {
+		return
+	}
+
+	claim := blueprint.NewClaim(claimGVR.GroupVersion().String(), claimKind, blueprint.ClaimSpec{
+		Name:       data.Name,
+		Namespace:  teamID,
+		Parameters: data.Parameters,
+	})
+
+	opLog := s.logger.WithValues("team", teamID, "action", "create-claim", "crossplane_gvk", claim.GroupVersionKind().String())
+
+	op, err := s.ops.Submit(teamID, func(ctx context.Context) (interface{}, error) {
+		applied, err := crossplane.Apply(ctx, clients.Dynamic, claimGVR, claim)
+		if err != nil {
+			opLog.Error(err, "failed to create claim")
+			return nil, fmt.Errorf("failed to create claim '%s': %w", data.Name, err)
+		}
+		opLog.Info("claim created")
+		return applied, nil
+	})
+	if err != nil {
+		writeError(w, err, http.StatusServiceUnavailable)
+		return
+	}
+	writeOperationAccepted(w, op)
+}
+
+// getTeamClaims lists every claim in the team's namespace, across all
+// registered blueprints.
+func (s *apiServer) getTeamClaims(w http.ResponseWriter, r *http.Request) {
+	teamID := mux.Vars(r)["team_id"]
+
+	clients, ok := s.authorizedClients(w, r, "list", blueprint.XRDGVR, teamID, "")
+	if !ok {
+		return
+	}
+
+	xrds, err := blueprint.ListXRDs(r.Context(), clients.Dynamic)
+	if err != nil {
+		writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	var claims []unstructured.Unstructured
+	for i := range xrds {
+		claimGVR, err := blueprint.ClaimGVR(&xrds[i])
+		if err != nil {
+			continue // XRD doesn't offer a claim
+		}
+		list, err := clients.Dynamic.Resource(claimGVR).Namespace(teamID).List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			continue
+		}
+		claims = append(claims, list.Items...)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"claims": claims})
+}
+
+// getOperation polls the current state of an operation.
+func (s *apiServer) getOperation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	op, err := s.ops.Get(id)
+	if err != nil {
+		writeError(w, fmt.Errorf("operation %q not found: %w", id, err), http.StatusNotFound)
+		return
+	}
+	if !s.checkAuthorized(w, r, "get", namespacesGVR, "", op.Namespace) {
+		return
+	}
+	json.NewEncoder(w).Encode(op)
+}
+
+// waitOperation long-polls an operation until it reaches a terminal state
+// or the requested timeout elapses.
+func (s *apiServer) waitOperation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	timeout := defaultWaitTimeout
+	if raw := r.URL.Query().Get("timeout"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid 'timeout' query param: %v", err), http.StatusBadRequest)
+			return
+		}
+		timeout = parsed
+	}
+
+	op, err := s.ops.Get(id)
+	if err != nil {
+		writeError(w, fmt.Errorf("operation %q not found: %w", id, err), http.StatusNotFound)
+		return
+	}
+	if !s.checkAuthorized(w, r, "get", namespacesGVR, "", op.Namespace) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	op, err = s.ops.Wait(ctx, id)
+	if err != nil {
+		writeError(w, fmt.Errorf("operation %q not found: %w", id, err), http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(op)
+}
+
+// cancelOperation requests cancellation of a running operation.
+func (s *apiServer) cancelOperation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	op, err := s.ops.Get(id)
+	if err != nil {
+		writeError(w, fmt.Errorf("operation %q not found: %w", id, err), http.StatusNotFound)
+		return
+	}
+	if !s.checkAuthorized(w, r, "delete", namespacesGVR, "", op.Namespace) {
+		return
+	}
+
+	if err := s.ops.Cancel(id); err != nil {
+		writeError(w, fmt.Errorf("failed to cancel operation %q: %w", id, err), http.StatusConflict)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": fmt.Sprintf("Cancellation requested for operation '%s'.", id)})
+}
+
 func main() {
-	router := mux.NewRouter()
+	logger, flushLogs, err := logging.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer flushLogs()
+
+	clients, err := k8s.NewClients()
+	if err != nil {
+		logger.Error(err, "failed to initialize Kubernetes clients")
+		os.Exit(1)
+	}
+
+	dbPath := os.Getenv("OPERATIONS_DB_PATH")
+	if dbPath == "" {
+		dbPath = defaultOperationsDBPath
+	}
+	opsStore, err := operations.OpenStore(dbPath)
+	if err != nil {
+		logger.Error(err, "failed to open operations store")
+		os.Exit(1)
+	}
+
+	opsManager := operations.NewManager(opsStore, operationWorkers, operationQueueSize)
+	metrics.RegisterRunningOperations(func() float64 { return float64(opsManager.Running()) })
+
+	srv := &apiServer{
+		clients:    clients,
+		watchCache: watch.NewCache(clients.Dynamic),
+		ops:        opsManager,
+		authorizer: auth.NewAuthorizer(clients.Typed),
+		logger:     logger,
+	}
 
-	router.HandleFunc("/", hello).Methods("GET")
-	router.HandleFunc("/teams", registerTeam).Methods("POST")
-	router.HandleFunc("/teams/{team_id}", getTeam).Methods("GET")
-	router.HandleFunc("/teams/{team_id}/vm", createVM).Methods("POST")
-	router.HandleFunc("/teams/{team_name}/vm/{vm_name}/resize", resizeVM).Methods("PUT")
-	router.HandleFunc("/teams/{team_id}/vm/{resource_id}/scale", scaleVM).Methods("PUT")
-	router.HandleFunc("/teams/{team_name}/vm/{vm_name}/attach-disk", attachDisk).Methods("POST")
-	router.HandleFunc("/teams/{team_name}/block", createBlockVolume).Methods("POST")
-	router.HandleFunc("/teams/{team_name}/vm/{vm_name}/{action}", handleVMAction).Methods("PUT") // Combined start/stop/delete via action
-	router.HandleFunc("/teams/{team_id}/vm/{resource_id}", deleteVM).Methods("DELETE")
+	authenticators := []auth.Authenticator{auth.NewServiceAccountAuthenticator(clients.Typed)}
+	if issuerURL := os.Getenv("OIDC_ISSUER_URL"); issuerURL != "" {
+		oidcAuthenticator, err := auth.NewOIDCAuthenticator(context.Background(), auth.OIDCConfig{
+			IssuerURL:     issuerURL,
+			ClientID:      os.Getenv("OIDC_CLIENT_ID"),
+			UsernameClaim: os.Getenv("OIDC_USERNAME_CLAIM"),
+			GroupsClaim:   os.Getenv("OIDC_GROUPS_CLAIM"),
+		})
+		if err != nil {
+			logger.Error(err, "failed to configure OIDC authenticator")
+			os.Exit(1)
+		}
+		authenticators = append(authenticators, oidcAuthenticator)
+	}
+
+	router := mux.NewRouter()
+	router.Use(metrics.Middleware)
+	router.Use(logging.Middleware(logger))
+	router.HandleFunc("/", srv.hello).Methods("GET")
+	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+
+	authed := router.PathPrefix("/").Subrouter()
+	authed.Use(auth.Middleware(authenticators...))
+
+	authed.HandleFunc("/providers", srv.listProviders).Methods("GET")
+	authed.HandleFunc("/teams", srv.registerTeam).Methods("POST")
+	authed.HandleFunc("/teams/{team_id}", srv.getTeam).Methods("GET")
+	authed.HandleFunc("/teams/{team_id}/vm", srv.createVM).Methods("POST")
+	authed.HandleFunc("/teams/{team_name}/vm/{vm_name}/resize", srv.resizeVM).Methods("PUT")
+	authed.HandleFunc("/teams/{team_id}/vm/{resource_id}/scale", srv.scaleVM).Methods("PUT")
+	authed.HandleFunc("/teams/{team_name}/vm/{vm_name}/attach-disk", srv.attachDisk).Methods("POST")
+	authed.HandleFunc("/teams/{team_name}/block", srv.createBlockVolume).Methods("POST")
+	authed.HandleFunc("/teams/{team_name}/vm/{vm_name}/{action}", srv.handleVMAction).Methods("PUT") // Combined start/stop/delete via action
+	authed.HandleFunc("/teams/{team_id}/vm/{resource_id}", srv.deleteVM).Methods("DELETE")
+	authed.HandleFunc("/teams/{team_id}/vm/{vm_name}/status", srv.vmStatus).Methods("GET")
+	authed.HandleFunc("/teams/{team_id}/events", srv.teamEvents).Methods("GET")
+	authed.HandleFunc("/blueprints", srv.registerBlueprint).Methods("POST")
+	authed.HandleFunc("/blueprints", srv.listBlueprints).Methods("GET")
+	authed.HandleFunc("/teams/{team_id}/claims/{blueprint}", srv.createClaim).Methods("POST")
+	authed.HandleFunc("/teams/{team_id}/claims", srv.getTeamClaims).Methods("GET")
+	authed.HandleFunc("/operations/{id}", srv.getOperation).Methods("GET")
+	authed.HandleFunc("/operations/{id}/wait", srv.waitOperation).Methods("GET")
+	authed.HandleFunc("/operations/{id}", srv.cancelOperation).Methods("DELETE")
 
 	port := "8080"
-	log.Printf("Server starting on port %s...", port)
-	log.Fatal(http.ListenAndServe(":"+port, router))
+	logger.Info("server starting", "port", port)
+	if err := http.ListenAndServe(":"+port, router); err != nil {
+		logger.Error(err, "server exited")
+		os.Exit(1)
+	}
 }